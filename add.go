@@ -3,7 +3,9 @@ package pile
 // Insert assigns the Value to the Key if and only if the Key is absent.
 func (m *Map[Key, Value]) Insert(k Key, v Value) bool {
 	if m.top == nil {
-		m.top = m.newNodeWith1(nil, pair[Key, Value]{K: k, V: v})
+		m.modN++
+		m.top = m.newNodeWith1(nil, k, v)
+		m.fixUp(m.top)
 		return true
 	}
 	t := m.top
@@ -15,15 +17,15 @@ func (m *Map[Key, Value]) Insert(k Key, v Value) bool {
 		if t.pairN == 3 {
 			// insert overflows
 			switch {
-			case k > t.pairs[1].K:
+			case k > t.keys[1]:
 				switch {
-				case k > t.pairs[2].K:
+				case k > t.keys[2]:
 					if t.subs[3] != nil {
 						t = t.subs[3]
 						continue
 					}
 					goto InsertFourthOverflow
-				case k < t.pairs[2].K:
+				case k < t.keys[2]:
 					if t.subs[2] != nil {
 						t = t.subs[2]
 						continue
@@ -31,8 +33,8 @@ func (m *Map[Key, Value]) Insert(k Key, v Value) bool {
 					goto InsertThirdOverflow
 				}
 				return false
-			case k <= t.pairs[0].K:
-				if k < t.pairs[0].K {
+			case k <= t.keys[0]:
+				if k < t.keys[0] {
 					if t.subs[0] != nil {
 						t = t.subs[0]
 						continue
@@ -40,7 +42,7 @@ func (m *Map[Key, Value]) Insert(k Key, v Value) bool {
 					goto InsertFirstOverflow
 				}
 				return false
-			case k < t.pairs[1].K:
+			case k < t.keys[1]:
 				if t.subs[1] != nil {
 					t = t.subs[1]
 					continue
@@ -51,95 +53,125 @@ func (m *Map[Key, Value]) Insert(k Key, v Value) bool {
 		}
 
 		switch {
-		case t.pairN == 2 && k >= t.pairs[1].K:
-			if k > t.pairs[1].K {
+		case t.pairN == 2 && k >= t.keys[1]:
+			if k > t.keys[1] {
 				if t.subs[2] != nil {
 					t = t.subs[2]
 					continue
 				}
-				t.pairs[2].K = k
-				t.pairs[2].V = v
+				t.keys[2] = k
+				t.values[2] = v
 				t.pairN++
+				m.modN++
+				m.fixUp(t)
 				return true
 			}
 			return false
 
-		case k > t.pairs[0].K:
+		case k > t.keys[0]:
 			if t.subs[1] != nil {
 				t = t.subs[1]
 				continue
 			}
-			t.pairs[2] = t.pairs[1] // redundant if pairN is 1
-			t.pairs[1].K = k
-			t.pairs[1].V = v
+			t.keys[2] = t.keys[1] // redundant if pairN is 1
+			t.values[2] = t.values[1]
+			t.keys[1] = k
+			t.values[1] = v
 			t.pairN++
+			m.modN++
+			m.fixUp(t)
 			return true
 
-		case k < t.pairs[0].K:
+		case k < t.keys[0]:
 			if t.subs[0] != nil {
 				t = t.subs[0]
 				continue
 			}
-			t.pairs[2] = t.pairs[1] // redundant if pairN is 1
-			t.pairs[1] = t.pairs[0]
-			t.pairs[0].K = k
-			t.pairs[0].V = v
+			t.keys[2] = t.keys[1] // redundant if pairN is 1
+			t.values[2] = t.values[1]
+			t.keys[1] = t.keys[0]
+			t.values[1] = t.values[0]
+			t.keys[0] = k
+			t.values[0] = v
 			t.pairN++
+			m.modN++
+			m.fixUp(t)
 			return true
 		}
 		return false
 	}
 
 InsertFirstOverflow:
-	m.split = t.pairs[0]
-	t.pairs[0].K = k
-	t.pairs[0].V = v
+	m.split.K = t.keys[0]
+	m.split.V = t.values[0]
+	t.keys[0] = k
+	t.values[0] = v
 	goto Branche2Right
 InsertSecondOverflow:
 	m.split.K = k
 	m.split.V = v
 Branche2Right:
 	t.pairN = 1
-	splitRight = m.newNodeWith2(t.above, t.pairs[1], t.pairs[2])
+	splitRight = m.newNodeWith2(t.above, t.keys[1], t.values[1], t.keys[2], t.values[2])
 	goto Overflow
 
 InsertThirdOverflow:
 	t.pairN = 2
 	m.split.K = k
 	m.split.V = v
-	splitRight = m.newNodeWith1(t.above, t.pairs[2])
+	splitRight = m.newNodeWith1(t.above, t.keys[2], t.values[2])
 	goto Overflow
 
 InsertFourthOverflow:
 	t.pairN = 2
-	m.split = t.pairs[2]
-	splitRight = m.newNodeWith1(t.above, pair[Key, Value]{K: k, V: v})
+	m.split.K = t.keys[2]
+	m.split.V = t.values[2]
+	splitRight = m.newNodeWith1(t.above, k, v)
 
 Overflow:
+	m.modN++
 	for t.above != nil {
 		above := t.above
+		if m.fix != nil {
+			m.fix(t)
+			m.fix(splitRight)
+		}
 		splitRight = m.takeSplit(above, t, splitRight, &m.split)
 		if splitRight == nil {
+			m.fixUp(above)
 			return true
 		}
 		t = above
 	}
+	if m.fix != nil {
+		m.fix(t)
+		m.fix(splitRight)
+	}
 
-	grow := m.newNodeWith1(nil, m.split)
+	grow := m.newNodeWith1(nil, m.split.K, m.split.V)
 	m.top.above = grow
 	splitRight.above = grow
 	grow.subs[0] = m.top
 	grow.subs[1] = splitRight
 	m.top = grow
+	m.fixUp(grow)
 	return true
 }
 
-// Put assigns the Value to the Key regardles whether the Key is present or not.
-// The result is equivalent to both m.Insert(k, v) || m.Update(k, v), and to
-// m.Update(k, v) || m.Insert(k, v).
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not. The result is equivalent to both m.Insert(k, v) || m.Update(k, v), and
+// to m.Update(k, v) || m.Insert(k, v).
 func (m *Map[Key, Value]) Put(k Key, v Value) {
+	if m.fix != nil {
+		if !m.Update(k, v) {
+			m.Insert(k, v)
+		}
+		return
+	}
+
 	if m.top == nil {
-		m.top = m.newNodeWith1(nil, pair[Key, Value]{K: k, V: v})
+		m.modN++
+		m.top = m.newNodeWith1(nil, k, v)
 		return
 	}
 	t := m.top
@@ -151,112 +183,121 @@ func (m *Map[Key, Value]) Put(k Key, v Value) {
 		if t.pairN == 3 {
 			// insert overflows
 			switch {
-			case k > t.pairs[1].K:
+			case k > t.keys[1]:
 				switch {
-				case k > t.pairs[2].K:
+				case k > t.keys[2]:
 					if t.subs[3] != nil {
 						t = t.subs[3]
 						continue
 					}
 					goto InsertFourthOverflow
-				case k < t.pairs[2].K:
+				case k < t.keys[2]:
 					if t.subs[2] != nil {
 						t = t.subs[2]
 						continue
 					}
 					goto InsertThirdOverflow
 				}
-				t.pairs[2].V = v // update
+				t.values[2] = v // update
 				return
-			case k <= t.pairs[0].K:
-				if k < t.pairs[0].K {
+			case k <= t.keys[0]:
+				if k < t.keys[0] {
 					if t.subs[0] != nil {
 						t = t.subs[0]
 						continue
 					}
 					goto InsertFirstOverflow
 				}
-				t.pairs[0].V = v // update
+				t.values[0] = v // update
 				return
-			case k < t.pairs[1].K:
+			case k < t.keys[1]:
 				if t.subs[1] != nil {
 					t = t.subs[1]
 					continue
 				}
 				goto InsertSecondOverflow
 			}
-			t.pairs[1].V = v // update
+			t.values[1] = v // update
 			return
 		}
 
 		switch {
-		case t.pairN == 2 && k >= t.pairs[1].K:
-			if k > t.pairs[1].K {
+		case t.pairN == 2 && k >= t.keys[1]:
+			if k > t.keys[1] {
 				if t.subs[2] != nil {
 					t = t.subs[2]
 					continue
 				}
-				t.pairs[2].K = k
-				t.pairs[2].V = v
+				t.keys[2] = k
+				t.values[2] = v
 				t.pairN++
+				m.modN++
 				return
 			}
-			t.pairs[1].V = v // update
+			t.values[1] = v // update
 			return
 
-		case k > t.pairs[0].K:
+		case k > t.keys[0]:
 			if t.subs[1] != nil {
 				t = t.subs[1]
 				continue
 			}
-			t.pairs[2] = t.pairs[1] // redundant if pairN is 1
-			t.pairs[1].K = k
-			t.pairs[1].V = v
+			t.keys[2] = t.keys[1] // redundant if pairN is 1
+			t.values[2] = t.values[1]
+			t.keys[1] = k
+			t.values[1] = v
 			t.pairN++
+			m.modN++
 			return
 
-		case k < t.pairs[0].K:
+		case k < t.keys[0]:
 			if t.subs[0] != nil {
 				t = t.subs[0]
 				continue
 			}
-			t.pairs[2] = t.pairs[1] // redundant if pairN is 1
-			t.pairs[1] = t.pairs[0]
-			t.pairs[0].K = k
-			t.pairs[0].V = v
+			t.keys[2] = t.keys[1] // redundant if pairN is 1
+			t.values[2] = t.values[1]
+			t.keys[1] = t.keys[0]
+			t.values[1] = t.values[0]
+			t.keys[0] = k
+			t.values[0] = v
 			t.pairN++
+			m.modN++
 			return
 		}
-		t.pairs[0].V = v // update
+		t.values[0] = v // update
 		return
 	}
 
 InsertFirstOverflow:
-	m.split = t.pairs[0]
-	t.pairs[0].K = k
-	t.pairs[0].V = v
+	m.split.K = t.keys[0]
+	m.split.V = t.values[0]
+	t.keys[0] = k
+	t.values[0] = v
 	goto Branche2Right
 InsertSecondOverflow:
 	m.split.K = k
 	m.split.V = v
 Branche2Right:
 	t.pairN = 1
-	splitRight = m.newNodeWith2(t.above, t.pairs[1], t.pairs[2])
+	splitRight = m.newNodeWith2(t.above, t.keys[1], t.values[1], t.keys[2], t.values[2])
 	goto Overflow
 
 InsertThirdOverflow:
 	t.pairN = 2
 	m.split.K = k
 	m.split.V = v
-	splitRight = m.newNodeWith1(t.above, t.pairs[2])
+	splitRight = m.newNodeWith1(t.above, t.keys[2], t.values[2])
 	goto Overflow
 
 InsertFourthOverflow:
 	t.pairN = 2
-	m.split = t.pairs[2]
-	splitRight = m.newNodeWith1(t.above, pair[Key, Value]{K: k, V: v})
+	m.split.K = t.keys[2]
+	m.split.V = t.values[2]
+	splitRight = m.newNodeWith1(t.above, k, v)
 
 Overflow:
+	m.modN++
 	for t.above != nil {
 		above := t.above
 		splitRight = m.takeSplit(above, t, splitRight, &m.split)
@@ -266,7 +307,7 @@ Overflow:
 		t = above
 	}
 
-	grow := m.newNodeWith1(nil, m.split)
+	grow := m.newNodeWith1(nil, m.split.K, m.split.V)
 	m.top.above = grow
 	splitRight.above = grow
 	grow.subs[0] = m.top
@@ -274,6 +315,86 @@ Overflow:
 	m.top = grow
 }
 
+// Appender inserts in ascending Key order at a low cost. Each Append resumes
+// on the ground level where the previous one ended, instead of searching from
+// the top. Keys out of order fall back to a regular Insert.
+type Appender[Key Sortable, Value any] struct {
+	m    *Map[Key, Value]
+	modN uint64 // structural modification count of m at last use
+
+	t *node[Key, Value] // ground level, right side
+}
+
+// Appender returns a new Appender for the Map. Other modifications on the Map
+// are permitted in between Appends, albeit at the cost of another search.
+func (m *Map[Key, Value]) Appender() Appender[Key, Value] {
+	return Appender[Key, Value]{m: m}
+}
+
+// Append is equivalent to Insert. The operation is most efficient when the Key
+// is more than all other Keys in the Map. Each pair still goes into a node, and
+// full nodes still split, which makes Append about 1.5 to 2 times as fast as an
+// Insert in ascending order, rather than anywhere near a slice append.
+func (a *Appender[Key, Value]) Append(k Key, v Value) bool {
+	m := a.m
+	if m.fix != nil {
+		return m.Insert(k, v)
+	}
+	t := a.t
+	if t == nil || a.modN != m.modN {
+		t = m.top
+		if t == nil {
+			m.modN++
+			m.top = m.newNodeWith1(nil, k, v)
+			a.t = m.top
+			a.modN = m.modN
+			return true
+		}
+		// down to bottom level, right side
+		for t.subs[t.pairN&3] != nil {
+			t = t.subs[t.pairN&3]
+		}
+		a.t = t
+		a.modN = m.modN
+	}
+
+	if k <= t.keys[t.pairN-1] {
+		return m.Insert(k, v)
+	}
+
+	m.modN++
+	a.modN = m.modN
+	if t.pairN < 3 {
+		t.keys[t.pairN] = k
+		t.values[t.pairN] = v
+		t.pairN++
+		return true
+	}
+
+	// insert fourth overflows
+	t.pairN = 2
+	m.split.K = t.keys[2]
+	m.split.V = t.values[2]
+	splitRight := m.newNodeWith1(t.above, k, v)
+	a.t = splitRight
+	for t.above != nil {
+		above := t.above
+		splitRight = m.takeSplit(above, t, splitRight, &m.split)
+		if splitRight == nil {
+			return true
+		}
+		t = above
+	}
+
+	grow := m.newNodeWith1(nil, m.split.K, m.split.V)
+	m.top.above = grow
+	splitRight.above = grow
+	grow.subs[0] = m.top
+	grow.subs[1] = splitRight
+	m.top = grow
+	return true
+}
+
 // TakeSplit adds node rightInsert next to fromSub in t, separated by the split.
 // The operation may cause another split (pointer update) with a new splitRight
 // (relative to t).
@@ -285,17 +406,23 @@ func (m *Map[Key, Value]) takeSplit(t, fromSub, rightInsert *node[Key, Value], s
 			t.subs[3] = t.subs[2]
 			t.subs[2] = t.subs[1]
 			t.subs[1] = rightInsert
-			t.pairs[2] = t.pairs[1]
-			t.pairs[1] = t.pairs[0]
-			t.pairs[0] = *split
+			t.keys[2] = t.keys[1]
+			t.values[2] = t.values[1]
+			t.keys[1] = t.keys[0]
+			t.values[1] = t.values[0]
+			t.keys[0] = split.K
+			t.values[0] = split.V
 		case t.subs[1]:
 			t.subs[3] = t.subs[2]
 			t.subs[2] = rightInsert
-			t.pairs[2] = t.pairs[1]
-			t.pairs[1] = *split
+			t.keys[2] = t.keys[1]
+			t.values[2] = t.values[1]
+			t.keys[1] = split.K
+			t.values[1] = split.V
 		case t.subs[2]:
 			t.subs[3] = rightInsert
-			t.pairs[2] = *split
+			t.keys[2] = split.K
+			t.values[2] = split.V
 		}
 
 		return nil
@@ -304,7 +431,7 @@ func (m *Map[Key, Value]) takeSplit(t, fromSub, rightInsert *node[Key, Value], s
 
 	switch fromSub {
 	case t.subs[0]: // rightInsert goes into second spot
-		splitRight = m.newNodeWith2(t.above, t.pairs[1], t.pairs[2])
+		splitRight = m.newNodeWith2(t.above, t.keys[1], t.values[1], t.keys[2], t.values[2])
 		if t.subs[1] != nil {
 			splitRight.subs[0] = t.subs[1]
 			splitRight.subs[0].above = splitRight
@@ -319,10 +446,11 @@ func (m *Map[Key, Value]) takeSplit(t, fromSub, rightInsert *node[Key, Value], s
 		}
 		t.subs[1] = rightInsert
 		t.pairN = 1
-		*split, t.pairs[0] = t.pairs[0], *split
+		split.K, t.keys[0] = t.keys[0], split.K
+		split.V, t.values[0] = t.values[0], split.V
 	case t.subs[1]: // rightInsert goes into third sport
 		t.pairN = 1
-		splitRight = m.newNodeWith2(t.above, t.pairs[1], t.pairs[2])
+		splitRight = m.newNodeWith2(t.above, t.keys[1], t.values[1], t.keys[2], t.values[2])
 		splitRight.subs[0] = rightInsert
 		splitRight.subs[0].above = splitRight
 		if t.subs[2] != nil {
@@ -336,7 +464,7 @@ func (m *Map[Key, Value]) takeSplit(t, fromSub, rightInsert *node[Key, Value], s
 		// pass split to upper level
 	case t.subs[2]: // rightInsert goes into fourth spot
 		t.pairN = 2
-		splitRight = m.newNodeWith1(t.above, t.pairs[2])
+		splitRight = m.newNodeWith1(t.above, t.keys[2], t.values[2])
 		splitRight.subs[0] = rightInsert
 		splitRight.subs[0].above = splitRight
 		if t.subs[3] != nil {
@@ -346,14 +474,15 @@ func (m *Map[Key, Value]) takeSplit(t, fromSub, rightInsert *node[Key, Value], s
 		// pass split to upper level
 	case t.subs[3]: // rightInsert goes into fifth spot
 		t.pairN = 2
-		splitRight = m.newNodeWith1(t.above, *split)
+		splitRight = m.newNodeWith1(t.above, split.K, split.V)
 		if t.subs[3] != nil {
 			splitRight.subs[0] = t.subs[3]
 			splitRight.subs[0].above = splitRight
 		}
 		splitRight.subs[1] = rightInsert
 		splitRight.subs[1].above = splitRight
-		*split = t.pairs[2]
+		split.K = t.keys[2]
+		split.V = t.values[2]
 	}
 	return splitRight
 }
@@ -0,0 +1,136 @@
+package pile
+
+// Monoid defines an aggregate on Values. Lift provides the aggregate of a
+// single Value. Combine joins two aggregates, with the one from lower Keys on
+// the left. Combine must be associative, with Identity as its neutral element.
+type Monoid[Value, Agg any] struct {
+	Identity Agg
+	Lift     func(Value) Agg
+	Combine  func(Agg, Agg) Agg
+}
+
+// AggMap provides sorted Key–Value registration with aggregates on Key ranges,
+// such as sums, counts, minimums or maximums. Each node keeps the aggregate of
+// its subtree up to date, which costs Combine calls per level on each write.
+// Use NewAggMap for instantiation. Do not copy the AggMap struct.
+type AggMap[Key Sortable, Value, Agg any] struct {
+	check noCopy
+
+	tree     augMap[Key, Value, Agg]
+	identity Agg
+}
+
+// NewAggMap returns a new AggMap with the aggregates defined by monoid.
+func NewAggMap[Key Sortable, Value, Agg any](monoid Monoid[Value, Agg]) *AggMap[Key, Value, Agg] {
+	if monoid.Lift == nil || monoid.Combine == nil {
+		panic("pile: AggMap monoid without Lift or Combine function")
+	}
+	m := &AggMap[Key, Value, Agg]{identity: monoid.Identity}
+	m.tree.init(monoid.Lift, monoid.Combine)
+	return m
+}
+
+// Size returns the number of Keys in the AggMap.
+func (m *AggMap[Key, Value, Agg]) Size() int { return m.tree.m.Size() }
+
+// Find returns the Value assigned to the Key.
+func (m *AggMap[Key, Value, Agg]) Find(k Key) (Value, bool) {
+	vp, _ := m.tree.findPointer(k)
+	if vp == nil {
+		var zero Value
+		return zero, false
+	}
+	return *vp, true
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (m *AggMap[Key, Value, Agg]) Insert(k Key, v Value) bool {
+	return m.tree.insert(k, v)
+}
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (m *AggMap[Key, Value, Agg]) Update(k Key, v Value) bool {
+	vp, t := m.tree.findPointer(k)
+	if vp == nil {
+		return false
+	}
+	*vp = v
+	m.tree.refresh(t)
+	return true
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not.
+func (m *AggMap[Key, Value, Agg]) Put(k Key, v Value) {
+	if !m.Update(k, v) {
+		m.tree.insert(k, v)
+	}
+}
+
+// Delete removes the Key from the AggMap, and it returns the Value removed,
+// with false for none.
+func (m *AggMap[Key, Value, Agg]) Delete(k Key) (Value, bool) {
+	return m.tree.delete(k)
+}
+
+// AggregateAll returns the aggregate of all Values in the AggMap.
+func (m *AggMap[Key, Value, Agg]) AggregateAll() Agg {
+	if m.tree.m.top == nil {
+		return m.identity
+	}
+	return aggOf(m.tree.m.top)
+}
+
+// Aggregate returns the aggregate of the Values with a Key in [lo, hi). The
+// cost is logarithmic to the size of the AggMap, as any subtree within range
+// contributes with its aggregate as a whole.
+func (m *AggMap[Key, Value, Agg]) Aggregate(lo, hi Key) Agg {
+	if !(lo < hi) {
+		return m.identity
+	}
+	return m.aggregate(m.identity, m.tree.m.top, &lo, &hi)
+}
+
+// Aggregate combines acc with the Values in t and below with a Key in [lo, hi).
+// A nil bound means that all Keys in t are within range on that side.
+func (m *AggMap[Key, Value, Agg]) aggregate(acc Agg, t *node[Key, augValue[Value, Agg]], lo, hi *Key) Agg {
+	if t == nil {
+		return acc
+	}
+	if lo == nil && hi == nil {
+		return m.tree.combine(acc, aggOf(t))
+	}
+
+	for i := 0; i <= t.pairN; i++ {
+		// subnode i has Keys between t.keys[i-1] and t.keys[i]
+		if i > 0 && hi != nil && t.keys[i-1] >= *hi {
+			break // remaining Keys too high
+		}
+		if i == t.pairN || lo == nil || t.keys[i] > *lo {
+			subLo, subHi := lo, hi
+			if i > 0 && lo != nil && t.keys[i-1] >= *lo {
+				subLo = nil
+			}
+			if i < t.pairN && hi != nil && t.keys[i] <= *hi {
+				subHi = nil
+			}
+			acc = m.aggregate(acc, t.subs[i], subLo, subHi)
+		}
+
+		if i < t.pairN {
+			k := t.keys[i]
+			if (lo == nil || k >= *lo) && (hi == nil || k < *hi) {
+				acc = m.tree.combine(acc, m.tree.lift(t.values[i].v))
+			}
+		}
+	}
+	return acc
+}
+
+// Ascend calls f for each Key–Value pair in the AggMap, ascending in Key order,
+// until f returns false.
+func (m *AggMap[Key, Value, Agg]) Ascend(f func(Key, Value) bool) {
+	m.tree.m.top.ascend(func(k Key, av augValue[Value, Agg]) bool {
+		return f(k, av.v)
+	})
+}
@@ -0,0 +1,91 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleAggMap() {
+	// bytes transferred per timestamp
+	traffic := pile.NewAggMap[int64, int, int](pile.Monoid[int, int]{
+		Lift:    func(n int) int { return n },
+		Combine: func(a, b int) int { return a + b },
+	})
+	traffic.Put(1000, 512)
+	traffic.Put(1010, 64)
+	traffic.Put(1020, 1024)
+	traffic.Put(1030, 8)
+
+	fmt.Println(traffic.Aggregate(1010, 1030), traffic.AggregateAll())
+	// Output: 1088 1608
+}
+
+func TestAggMap(t *testing.T) {
+	// concatenation is not commutative, which verifies the order
+	m := pile.NewAggMap[uint16, string, string](pile.Monoid[string, string]{
+		Lift:    func(s string) string { return s },
+		Combine: func(a, b string) string { return a + b },
+	})
+	const keyN = 600
+	var reference [keyN]string
+
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 3000; i++ {
+		k := uint16(r.Intn(keyN))
+		v := string(rune('a' + r.Intn(26)))
+		switch r.Intn(4) {
+		case 0:
+			if m.Insert(k, v) != (reference[k] == "") {
+				t.Fatalf("insert key %d got wrong presence", k)
+			}
+			if reference[k] == "" {
+				reference[k] = v
+			}
+		case 1:
+			if m.Update(k, v) != (reference[k] != "") {
+				t.Fatalf("update key %d got wrong presence", k)
+			}
+			if reference[k] != "" {
+				reference[k] = v
+			}
+		case 2:
+			got, ok := m.Delete(k)
+			if ok != (reference[k] != "") || got != reference[k] {
+				t.Fatalf("delete key %d got (%q, %t), want %q", k, got, ok, reference[k])
+			}
+			reference[k] = ""
+		default:
+			m.Put(k, v)
+			reference[k] = v
+		}
+	}
+
+	if got, want := m.AggregateAll(), strings.Join(reference[:], ""); got != want {
+		t.Errorf("aggregate all got %q, want %q", got, want)
+	}
+	var n int
+	for _, v := range reference {
+		if v != "" {
+			n++
+		}
+	}
+	if got := m.Size(); got != n {
+		t.Errorf("got size %d, want %d", got, n)
+	}
+
+	for i := 0; i < 1000; i++ {
+		lo := r.Intn(keyN + 10)
+		hi := r.Intn(keyN + 10)
+		var want string
+		for k := lo; k < hi && k < keyN; k++ {
+			want += reference[k]
+		}
+		if got := m.Aggregate(uint16(lo), uint16(hi)); got != want {
+			t.Fatalf("aggregate [%d, %d) got %q, want %q", lo, hi, got, want)
+		}
+	}
+}
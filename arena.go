@@ -0,0 +1,119 @@
+package pile
+
+// Default range for the number of nodes per batch.
+const (
+	batchMinDefault = 1
+	batchMaxDefault = 512
+)
+
+// Arena allocates nodes in batches. Each Map has an Arena of its own, unless
+// UseArena applies a shared one. The first batch has BatchMin nodes, and each
+// following batch doubles in size, up to BatchMax. The zero Arena is ready for
+// use. Do not copy the Arena struct.
+//
+// An Arena is not safe for concurrent use. Maps which share an Arena must not
+// be modified simultaneously.
+type Arena[Key Sortable, Value any] struct {
+	check noCopy
+
+	// BatchMin sets the number of nodes in the first batch.
+	// Zero defaults to one node.
+	BatchMin int
+
+	// BatchMax limits the number of nodes per batch.
+	// Zero defaults to 512 nodes.
+	BatchMax int
+
+	batchN int                // size of last batch
+	free   []node[Key, Value] // unused remainder of last batch
+
+	recycled  *node[Key, Value] // linked with the above field
+	recycledN int               // number of nodes recycled
+}
+
+// Clear removes all Keys from the Map. The nodes go back into the Arena for
+// reuse.
+func (m *Map[Key, Value]) Clear() {
+	if m.top != nil {
+		if m.arena != nil {
+			m.arena.recycle(m.top)
+		} else {
+			m.nodes.recycle(m.top)
+		}
+		m.top = nil
+	}
+	m.modN++
+	m.split = pair[Key, Value]{}
+}
+
+// Release removes all Keys from the Map, and it frees all memory allocated. A
+// shared Arena, if any, remains in use, with the nodes back for reuse, as with
+// Clear.
+func (m *Map[Key, Value]) Release() {
+	if m.arena != nil {
+		m.Clear()
+		return
+	}
+	m.top = nil
+	m.modN++
+	m.split = pair[Key, Value]{}
+	m.nodes = Arena[Key, Value]{}
+}
+
+// UseArena makes the Map allocate nodes from the Arena. Multiple Maps can share
+// the same Arena. Nodes allocated before remain in use.
+func (m *Map[Key, Value]) UseArena(a *Arena[Key, Value]) {
+	m.arena = a
+}
+
+// SpareNodeN returns the number of nodes allocated for future use.
+func (a *Arena[Key, Value]) SpareNodeN() int {
+	return len(a.free) + a.recycledN
+}
+
+// Recycle makes t and all nodes below available for reuse.
+func (a *Arena[Key, Value]) recycle(t *node[Key, Value]) {
+	if t.subs[0] != nil {
+		for _, sub := range t.subs[:t.pairN+1] {
+			a.recycle(sub)
+		}
+	}
+	a.recycleNode(t)
+}
+
+// RecycleNode makes t available for reuse. Any nodes below are not affected.
+func (a *Arena[Key, Value]) recycleNode(t *node[Key, Value]) {
+	*t = node[Key, Value]{above: a.recycled} // release any references
+	a.recycled = t
+	a.recycledN++
+}
+
+func (a *Arena[Key, Value]) newNode() *node[Key, Value] {
+	if t := a.recycled; t != nil {
+		a.recycled = t.above
+		a.recycledN--
+		t.above = nil
+		return t
+	}
+
+	if len(a.free) == 0 {
+		a.batchN *= 2
+		if a.batchN == 0 {
+			a.batchN = a.BatchMin
+			if a.batchN <= 0 {
+				a.batchN = batchMinDefault
+			}
+		}
+		max := a.BatchMax
+		if max <= 0 {
+			max = batchMaxDefault
+		}
+		if a.batchN > max {
+			a.batchN = max
+		}
+		a.free = make([]node[Key, Value], a.batchN)
+	}
+	t := &a.free[len(a.free)-1]
+	a.free = a.free[:len(a.free)-1]
+	return t
+}
@@ -0,0 +1,119 @@
+package pile
+
+import "testing"
+
+func TestArenaGrowth(t *testing.T) {
+	var m Map[int, int]
+	m.Put(1, 1)
+	m.Put(2, 2)
+	m.Put(3, 3)
+	if s := m.Stats(); s.NodeN != 1 || s.SpareNodeN != 0 {
+		t.Errorf("got %d nodes plus %d spare for 3 pairs, want 1 node without spare", s.NodeN, s.SpareNodeN)
+	}
+
+	a := Arena[int, int]{BatchMin: 4, BatchMax: 16}
+	var capped Map[int, int]
+	capped.UseArena(&a)
+	var batchNs []int
+	for i := 0; i < 1000; i++ {
+		capped.Insert(i, i)
+		if a.batchN != 0 && (len(batchNs) == 0 || batchNs[len(batchNs)-1] != a.batchN) {
+			batchNs = append(batchNs, a.batchN)
+		}
+	}
+	if len(batchNs) != 3 || batchNs[0] != 4 || batchNs[1] != 8 || batchNs[2] != 16 {
+		t.Errorf("got batch sizes %d, want 4, 8, 16", batchNs)
+	}
+	s := capped.Stats()
+	if (s.NodeN+s.SpareNodeN-4-8)%16 != 0 {
+		t.Errorf("got %d nodes plus %d spare, want 4 + 8 + a multiple of 16", s.NodeN, s.SpareNodeN)
+	}
+}
+
+func TestArenaShared(t *testing.T) {
+	a := Arena[string, int]{BatchMin: 100, BatchMax: 100}
+	maps := make([]Map[string, int], 100)
+	for i := range maps {
+		maps[i].UseArena(&a)
+	}
+
+	for i := range maps {
+		maps[i].Insert("a", i)
+		maps[i].Insert("b", i)
+		maps[i].Insert("c", i)
+	}
+	// one node per Map
+	if a.batchN != 100 || a.SpareNodeN() != 0 {
+		t.Errorf("got batch size %d with %d spare, want all 100 nodes from one batch", a.batchN, a.SpareNodeN())
+	}
+
+	for i := range maps {
+		verifyMapEqual(t, "shared", &maps[i], map[string]int{"a": i, "b": i, "c": i})
+	}
+}
+
+func TestClear(t *testing.T) {
+	var m Map[int, string]
+	fill := func() {
+		for i := 0; i < 1000; i++ {
+			m.Insert(i, "x")
+		}
+	}
+
+	fill()
+	c, _ := m.Least()
+	nodeN := m.Stats().NodeN
+	m.Clear()
+	verifyMapEqual(t, "Clear", &m, map[int]string{})
+	if _, ok := m.Least(); ok {
+		t.Error("got a least Key after Clear")
+	}
+	if s := m.Stats(); s.SpareNodeN < nodeN {
+		t.Errorf("got %d spare nodes after Clear, want at least the %d nodes in use before", s.SpareNodeN, nodeN)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Cursor use after Clear did not panic")
+			}
+		}()
+		c.Key()
+	}()
+
+	allocN := testing.AllocsPerRun(2, func() {
+		fill()
+		m.Clear()
+	})
+	if allocN != 0 {
+		t.Errorf("refill after Clear allocated %f times, want 0", allocN)
+	}
+
+	fill()
+	reference := make(map[int]string, 1000)
+	for i := 0; i < 1000; i++ {
+		reference[i] = "x"
+	}
+	verifyMapEqual(t, "refill", &m, reference)
+}
+
+func TestRelease(t *testing.T) {
+	var m Map[int, string]
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, "x")
+	}
+	m.Release()
+	verifyMapEqual(t, "Release", &m, map[int]string{})
+	if s := m.Stats(); s.ByteN != 0 {
+		t.Errorf("got %d bytes after Release, want 0", s.ByteN)
+	}
+	m.Put(1, "y")
+	verifyMapEqual(t, "reuse", &m, map[int]string{1: "y"})
+
+	var shared Arena[int, string]
+	m.UseArena(&shared)
+	m.Put(2, "z")
+	m.Release()
+	if n := shared.SpareNodeN(); n == 0 {
+		t.Error("shared Arena got no spare nodes after Release")
+	}
+}
@@ -0,0 +1,103 @@
+package pile
+
+// AugValue is a Value in an augMap. The aggregate applies to the first pair of
+// each node only, as nodes have no field of their own for it. Pairs may move to
+// another slot or node on Insert and Delete, after which the fix hook of the
+// Map restores the aggregates.
+type augValue[Value, Agg any] struct {
+	v   Value
+	agg Agg // aggregate of the subtree, if first in node
+}
+
+// Augment maintains aggregates on the nodes of a Map. The lift function
+// provides the aggregate of a single Value, and combine joins two aggregates in
+// Key order. Combine must be associative.
+type augment[Key Sortable, Value, Agg any] struct {
+	lift    func(Value) Agg
+	combine func(Agg, Agg) Agg
+}
+
+// AugMap is a Map with an aggregate per node. The aggregate covers all pairs in
+// the node plus all pairs in the nodes below. Do not copy the augMap struct.
+type augMap[Key Sortable, Value, Agg any] struct {
+	m Map[Key, augValue[Value, Agg]]
+	augment[Key, Value, Agg]
+}
+
+func (tree *augMap[Key, Value, Agg]) init(lift func(Value) Agg, combine func(Agg, Agg) Agg) {
+	tree.augment = augment[Key, Value, Agg]{lift: lift, combine: combine}
+	tree.m.fix = tree.update
+}
+
+// Agg returns the aggregate of t and below. Node t must not be nil.
+func aggOf[Key Sortable, Value, Agg any](t *node[Key, augValue[Value, Agg]]) Agg {
+	return t.values[0].agg
+}
+
+// Update sets the aggregate of t from its pairs and the aggregates below.
+func (a *augment[Key, Value, Agg]) update(t *node[Key, augValue[Value, Agg]]) {
+	if t.subs[0] == nil {
+		agg := a.lift(t.values[0].v)
+		for i := 1; i < t.pairN; i++ {
+			agg = a.combine(agg, a.lift(t.values[i].v))
+		}
+		t.values[0].agg = agg
+		return
+	}
+
+	agg := aggOf(t.subs[0])
+	for i := 0; i < t.pairN; i++ {
+		agg = a.combine(agg, a.lift(t.values[i].v))
+		agg = a.combine(agg, aggOf(t.subs[i+1]))
+	}
+	t.values[0].agg = agg
+}
+
+// AggregateBelow combines acc with the Values in t and below with a Key less
+// than k. The cost is logarithmic, as it follows one path only.
+func (a *augment[Key, Value, Agg]) aggregateBelow(acc Agg, t *node[Key, augValue[Value, Agg]], k Key) Agg {
+	for t != nil {
+		i := 0
+		for ; i < t.pairN && t.keys[i] < k; i++ {
+			if t.subs[0] != nil {
+				acc = a.combine(acc, aggOf(t.subs[i]))
+			}
+			acc = a.combine(acc, a.lift(t.values[i].v))
+		}
+		if i < t.pairN && t.keys[i] == k {
+			if t.subs[0] != nil {
+				acc = a.combine(acc, aggOf(t.subs[i]))
+			}
+			break
+		}
+		t = t.subs[i]
+	}
+	return acc
+}
+
+// FindPointer returns the Value assigned to the Key, with nil for none. Any
+// modification through the pointer must be followed by a refresh on the node.
+func (tree *augMap[Key, Value, Agg]) findPointer(k Key) (*Value, *node[Key, augValue[Value, Agg]]) {
+	t, pairI := tree.m.top.locate(k) // nil safe
+	if t == nil {
+		return nil, nil
+	}
+	return &t.values[pairI].v, t
+}
+
+// Refresh updates the aggregates of t and of each node above t.
+func (tree *augMap[Key, Value, Agg]) refresh(t *node[Key, augValue[Value, Agg]]) {
+	tree.m.fixUp(t)
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (tree *augMap[Key, Value, Agg]) insert(k Key, v Value) bool {
+	return tree.m.Insert(k, augValue[Value, Agg]{v: v})
+}
+
+// Delete removes the Key, and it returns the Value removed, with false for
+// none.
+func (tree *augMap[Key, Value, Agg]) delete(k Key) (Value, bool) {
+	av, ok := tree.m.Delete(k)
+	return av.v, ok
+}
@@ -0,0 +1,64 @@
+package pile
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestAugMapFix verifies the aggregates after each kind of write on the Map.
+func TestAugMapFix(t *testing.T) {
+	var tree augMap[int, int, int]
+	tree.init(func(v int) int { return v }, func(a, b int) int { return a + b })
+	m := &tree.m
+
+	r := rand.New(rand.NewSource(3))
+	appender := m.Appender()
+	var appendK int
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(1000)
+		v := augValue[int, int]{v: i}
+		switch r.Intn(6) {
+		case 0:
+			m.Insert(k, v)
+		case 1:
+			m.Put(k, v)
+		case 2:
+			m.Update(k, v)
+		case 3:
+			m.Delete(k)
+		case 4:
+			appendK += r.Intn(3)
+			appender.Append(appendK, v)
+		default:
+			if c, ok := m.At(k); ok {
+				c.Swap(v)
+			}
+		}
+
+		if m.top != nil {
+			verifyAggSum(t, m.top)
+		}
+		if t.Failed() {
+			t.Fatalf("aggregates broken after operation %d", i)
+		}
+	}
+}
+
+// VerifyAggSum returns the sum of all Values in n and below, and it checks the
+// aggregate of each node against that sum.
+func verifyAggSum(t *testing.T, n *node[int, augValue[int, int]]) int {
+	var sum int
+	for i := 0; i < n.pairN; i++ {
+		sum += n.values[i].v
+		if n.subs[0] != nil {
+			sum += verifyAggSum(t, n.subs[i])
+		}
+	}
+	if n.subs[0] != nil {
+		sum += verifyAggSum(t, n.subs[n.pairN])
+	}
+	if got := n.values[0].agg; got != sum {
+		t.Errorf("node aggregate %d, want sum %d", got, sum)
+	}
+	return sum
+}
@@ -0,0 +1,77 @@
+package pile
+
+// Bag counts occurrences per Key. Keys are present while their count is above
+// zero. A secondary order on counts serves frequency queries. The zero Bag is
+// empty and ready for use. Do not copy the Bag struct.
+type Bag[Key Sortable] struct {
+	check noCopy
+
+	counts  Map[Key, int]
+	byCount subMaps[int, Key, struct{}] // Keys per count
+	total   int                         // sum of counts
+}
+
+// Size returns the number of Keys in the Bag.
+func (b *Bag[Key]) Size() int { return b.counts.Size() }
+
+// Total returns the sum of all counts in the Bag.
+func (b *Bag[Key]) Total() int { return b.total }
+
+// Count returns the number of occurrences of the Key, with zero for none.
+func (b *Bag[Key]) Count(k Key) int {
+	n, _ := b.counts.Find(k)
+	return n
+}
+
+// Add adjusts the count of the Key with delta, and it returns the new count.
+// The Key is removed when its count reaches zero or less, in which case the
+// return is zero.
+func (b *Bag[Key]) Add(k Key, delta int) int {
+	if delta == 0 {
+		return b.Count(k)
+	}
+
+	n, ok := b.counts.Find(k)
+	if ok {
+		b.byCount.delete(n, k)
+	}
+	newN := n + delta
+	if newN <= 0 {
+		if ok {
+			b.counts.Delete(k)
+		}
+		b.total -= n
+		return 0
+	}
+
+	if ok {
+		b.counts.Update(k, newN)
+	} else {
+		b.counts.Insert(k, newN)
+	}
+	b.total += newN - n
+	b.byCount.subFor(newN).Insert(k, struct{}{})
+	return newN
+}
+
+// MostFrequent returns up to n Keys with the highest counts, in descending
+// order of count. Keys with an equal count go in ascending Key order.
+func (b *Bag[Key]) MostFrequent(n int) []Key {
+	if n <= 0 {
+		return nil
+	}
+	if size := b.Size(); n > size {
+		n = size
+	}
+	top := make([]Key, 0, n)
+	for c, ok := b.byCount.byA.Most(); ok; ok = c.Descend() {
+		keys := c.Value()
+		for kc, ok := keys.Least(); ok; ok = kc.Ascend() {
+			if len(top) == n {
+				return top
+			}
+			top = append(top, kc.Key())
+		}
+	}
+	return top
+}
@@ -0,0 +1,87 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleBag() {
+	var words pile.Bag[string]
+	for _, w := range []string{"to", "be", "or", "not", "to", "be"} {
+		words.Add(w, 1)
+	}
+	fmt.Println(words.MostFrequent(3), words.Total())
+	// Output: [be to not] 6
+}
+
+func TestBag(t *testing.T) {
+	var b pile.Bag[uint8]
+	reference := make(map[uint8]int)
+	r := rand.New(rand.NewSource(99))
+	for i := 0; i < 10000; i++ {
+		k := uint8(r.Intn(64))
+		delta := r.Intn(7) - 2
+		got := b.Add(k, delta)
+
+		want := reference[k] + delta
+		if want <= 0 {
+			want = 0
+			delete(reference, k)
+		} else {
+			reference[k] = want
+		}
+		if got != want {
+			t.Fatalf("add %d to key %d got count %d, want %d", delta, k, got, want)
+		}
+	}
+
+	if got, want := b.Size(), len(reference); got != want {
+		t.Errorf("got size %d, want %d", got, want)
+	}
+	var total int
+	for k, n := range reference {
+		total += n
+		if got := b.Count(k); got != n {
+			t.Errorf("key %d got count %d, want %d", k, got, n)
+		}
+	}
+	if got := b.Total(); got != total {
+		t.Errorf("got total %d, want %d", got, total)
+	}
+
+	want := make([]uint8, 0, len(reference))
+	for k := range reference {
+		want = append(want, k)
+	}
+	sort.Slice(want, func(i, j int) bool {
+		ni, nj := reference[want[i]], reference[want[j]]
+		return ni > nj || ni == nj && want[i] < want[j]
+	})
+	if got := b.MostFrequent(10); !reflect.DeepEqual(got, want[:10]) {
+		t.Errorf("got most frequent %d, want %d", got, want[:10])
+	}
+	if got := b.MostFrequent(1000); !reflect.DeepEqual(got, want) {
+		t.Errorf("got most frequent %d, want all %d", got, want)
+	}
+	if got := b.MostFrequent(0); got != nil {
+		t.Errorf("got most frequent %d for zero", got)
+	}
+
+	// empty out
+	for k, n := range reference {
+		if got := b.Add(k, -n); got != 0 {
+			t.Errorf("add %d to key %d got count %d, want 0", -n, k, got)
+		}
+	}
+	if b.Size() != 0 || b.Total() != 0 {
+		t.Errorf("got size %d, total %d after removal of all", b.Size(), b.Total())
+	}
+	if got := b.MostFrequent(1); len(got) != 0 {
+		t.Errorf("got most frequent %d after removal of all", got)
+	}
+}
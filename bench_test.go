@@ -86,6 +86,30 @@ func BenchmarkMapFind(b *testing.B) {
 			}
 		})
 	})
+
+	// Value sizes with 1Mi random keys
+	b.Run("Value", func(b *testing.B) {
+		feed := nRandomInts(1024 * 1024)
+		b.Run("8B", func(b *testing.B) { benchmarkMapFindValue[[8]byte](b, feed) })
+		b.Run("32B", func(b *testing.B) { benchmarkMapFindValue[[32]byte](b, feed) })
+		b.Run("128B", func(b *testing.B) { benchmarkMapFindValue[[128]byte](b, feed) })
+		b.Run("512B", func(b *testing.B) { benchmarkMapFindValue[[512]byte](b, feed) })
+	})
+}
+
+func benchmarkMapFindValue[Value any](b *testing.B, feed []int) {
+	var m Map[int, Value]
+	var v Value
+	for _, k := range feed {
+		m.Put(k, v)
+	}
+	mask := len(feed) - 1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if m.FindPointer(feed[i&mask]) == nil {
+			b.Fatalf("key %d not found", i&mask)
+		}
+	}
 }
 
 func BenchmarkInsert(b *testing.B) {
@@ -106,6 +130,15 @@ func BenchmarkInsert(b *testing.B) {
 				}
 			}
 		})
+		b.Run("appender", func(b *testing.B) {
+			var m Map[int, string]
+			a := m.Appender()
+			for i := 0; i < b.N; i++ {
+				if !a.Append(i, "foo") {
+					b.Fatalf("insertion %d denied", i)
+				}
+			}
+		})
 	})
 
 	b.Run("Prepend", func(b *testing.B) {
@@ -174,3 +207,96 @@ func nRandomInts(n int) []int {
 	}
 	return ints
 }
+
+// BenchmarkFanout compares the Map against the WideMap with random keys.
+func BenchmarkFanout(b *testing.B) {
+	feed := nRandomInts(1024 * 1024)
+
+	for _, size := range []struct {
+		name string
+		n    int
+	}{{"1Ki", 1024}, {"1Mi", 1024 * 1024}} {
+		mask := size.n - 1
+
+		b.Run("Find/"+size.name, func(b *testing.B) {
+			b.Run("2-3-4", func(b *testing.B) {
+				var m Map[int, string]
+				for _, k := range feed[:size.n] {
+					m.Put(k, "fill")
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, ok := m.Find(feed[i&mask]); !ok {
+						b.Fatalf("key %d not found", i&mask)
+					}
+				}
+			})
+			b.Run("wide", func(b *testing.B) {
+				var m WideMap[int, string]
+				for _, k := range feed[:size.n] {
+					m.Put(k, "fill")
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, ok := m.Find(feed[i&mask]); !ok {
+						b.Fatalf("key %d not found", i&mask)
+					}
+				}
+			})
+		})
+
+		b.Run("Insert/"+size.name, func(b *testing.B) {
+			b.Run("2-3-4", func(b *testing.B) {
+				var m Map[int, string]
+				for i := 0; i < b.N; i++ {
+					if i&mask == 0 {
+						m.Release()
+					}
+					m.Insert(feed[i&mask], "fill")
+				}
+			})
+			b.Run("wide", func(b *testing.B) {
+				m := new(WideMap[int, string])
+				for i := 0; i < b.N; i++ {
+					if i&mask == 0 {
+						m = new(WideMap[int, string])
+					}
+					m.Insert(feed[i&mask], "fill")
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkCursor measures iteration cost per Key.
+func BenchmarkCursor(b *testing.B) {
+	var m Map[int, int]
+	for i := 0; i < 1024*1024; i++ {
+		m.Put(i, i)
+	}
+
+	b.Run("Ascend", func(b *testing.B) {
+		var sum int
+		c, ok := m.Least()
+		for i := 0; i < b.N; i++ {
+			if !ok {
+				c, ok = m.Least()
+			}
+			sum += c.Key()
+			ok = c.Ascend()
+		}
+		_ = sum
+	})
+	b.Run("Descend", func(b *testing.B) {
+		var sum int
+		c, ok := m.Most()
+		for i := 0; i < b.N; i++ {
+			if !ok {
+				c, ok = m.Most()
+			}
+			sum += c.Key()
+			ok = c.Descend()
+		}
+		_ = sum
+	})
+}
@@ -0,0 +1,140 @@
+package pile
+
+// BiMap provides sorted Key–Value registration with unique Values. Lookups and
+// iteration work in both directions. The zero BiMap is empty and ready for use.
+// Do not copy the BiMap struct.
+type BiMap[Key, Value Sortable] struct {
+	check noCopy
+
+	forward Map[Key, Value]
+	inverse Map[Value, Key]
+}
+
+// Size returns the number of Key–Value pairs in the BiMap.
+func (m *BiMap[Key, Value]) Size() int { return m.forward.Size() }
+
+// Find returns the Value assigned to the Key.
+func (m *BiMap[Key, Value]) Find(k Key) (Value, bool) { return m.forward.Find(k) }
+
+// FindKey returns the Key assigned to the Value.
+func (m *BiMap[Key, Value]) FindKey(v Value) (Key, bool) { return m.inverse.Find(v) }
+
+// Insert assigns the Value to the Key if and only if both the Key and the Value
+// are absent.
+func (m *BiMap[Key, Value]) Insert(k Key, v Value) bool {
+	if m.forward.FindPointer(k) != nil || m.inverse.FindPointer(v) != nil {
+		return false
+	}
+	m.forward.Insert(k, v)
+	m.inverse.Insert(v, k)
+	return true
+}
+
+// Put assigns the Value to the Key regardless whether the Key or the Value is
+// present or not. Any pair with either the Key or the Value is evicted first.
+// The return has the number of pairs evicted, which is 0, 1 or 2.
+func (m *BiMap[Key, Value]) Put(k Key, v Value) (evictN int) {
+	if oldV, ok := m.forward.Find(k); ok {
+		if oldV == v {
+			return 0 // no change
+		}
+		m.inverse.Delete(oldV)
+		m.forward.Delete(k)
+		evictN++
+	}
+	if oldK, ok := m.inverse.Delete(v); ok {
+		m.forward.Delete(oldK)
+		evictN++
+	}
+	m.forward.Insert(k, v)
+	m.inverse.Insert(v, k)
+	return evictN
+}
+
+// Delete removes the Key, including its Value, from the BiMap, and it returns
+// the Value removed, with false for none.
+func (m *BiMap[Key, Value]) Delete(k Key) (Value, bool) {
+	v, ok := m.forward.Delete(k)
+	if ok {
+		m.inverse.Delete(v)
+	}
+	return v, ok
+}
+
+// DeleteValue removes the Value, including its Key, from the BiMap, and it
+// returns the Key removed, with false for none.
+func (m *BiMap[Key, Value]) DeleteValue(v Value) (Key, bool) {
+	k, ok := m.inverse.Delete(v)
+	if ok {
+		m.forward.Delete(k)
+	}
+	return k, ok
+}
+
+// Least returns a new BiCursor located at the Key which is less than all others
+// in the BiMap. The return is false when BiMap is empty. A Delete or Insert
+// renders the BiCursor invalid.
+func (m *BiMap[Key, Value]) Least() (BiCursor[Key, Value], bool) {
+	c, ok := m.forward.Least()
+	return BiCursor[Key, Value]{c}, ok
+}
+
+// Most returns a new BiCursor located at the Key which is more than all others
+// in the BiMap. The return is false when BiMap is empty. A Delete or Insert
+// renders the BiCursor invalid.
+func (m *BiMap[Key, Value]) Most() (BiCursor[Key, Value], bool) {
+	c, ok := m.forward.Most()
+	return BiCursor[Key, Value]{c}, ok
+}
+
+// At returns a new BiCursor located at the Key, with false for none. A Delete
+// or Insert renders the BiCursor invalid.
+func (m *BiMap[Key, Value]) At(k Key) (BiCursor[Key, Value], bool) {
+	c, ok := m.forward.At(k)
+	return BiCursor[Key, Value]{c}, ok
+}
+
+// LeastValue returns a new BiCursor located at the Value which is less than all
+// others in the BiMap. The BiCursor navigates in Value order, with the roles of
+// Key and Value swapped. The return is false when BiMap is empty. A Delete or
+// Insert renders the BiCursor invalid.
+func (m *BiMap[Key, Value]) LeastValue() (BiCursor[Value, Key], bool) {
+	c, ok := m.inverse.Least()
+	return BiCursor[Value, Key]{c}, ok
+}
+
+// MostValue returns a new BiCursor located at the Value which is more than all
+// others in the BiMap. The BiCursor navigates in Value order, with the roles of
+// Key and Value swapped. The return is false when BiMap is empty. A Delete or
+// Insert renders the BiCursor invalid.
+func (m *BiMap[Key, Value]) MostValue() (BiCursor[Value, Key], bool) {
+	c, ok := m.inverse.Most()
+	return BiCursor[Value, Key]{c}, ok
+}
+
+// AtValue returns a new BiCursor located at the Value, with false for none. The
+// BiCursor navigates in Value order, with the roles of Key and Value swapped. A
+// Delete or Insert renders the BiCursor invalid.
+func (m *BiMap[Key, Value]) AtValue(v Value) (BiCursor[Value, Key], bool) {
+	c, ok := m.inverse.At(v)
+	return BiCursor[Value, Key]{c}, ok
+}
+
+// BiCursor navigates over the pairs of a BiMap. Unlike Cursor, it does not
+// support Swap, as the Values must remain unique. Any use of an invalid
+// BiCursor panics.
+type BiCursor[Key, Value Sortable] struct {
+	c Cursor[Key, Value]
+}
+
+// Key returns the Key at the current position.
+func (c *BiCursor[Key, Value]) Key() Key { return c.c.Key() }
+
+// Value returns the Value at the current position.
+func (c *BiCursor[Key, Value]) Value() Value { return c.c.Value() }
+
+// Ascend moves the BiCursor one Key closer to Most, up to Most itself.
+func (c *BiCursor[Key, Value]) Ascend() bool { return c.c.Ascend() }
+
+// Descend moves the BiCursor one Key closer to Least, up to Least itself.
+func (c *BiCursor[Key, Value]) Descend() bool { return c.c.Descend() }
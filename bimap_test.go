@@ -0,0 +1,111 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleBiMap() {
+	var users pile.BiMap[int, string]
+	users.Put(42, "bob")
+	users.Put(7, "alice")
+	users.Put(99, "bob") // evicts 42
+
+	for c, ok := users.LeastValue(); ok; ok = c.Ascend() {
+		fmt.Println(c.Key(), c.Value())
+	}
+	// Output:
+	// alice 7
+	// bob 99
+}
+
+func TestBiMap(t *testing.T) {
+	var m pile.BiMap[uint8, int16]
+	forward := make(map[uint8]int16)
+	inverse := make(map[int16]uint8)
+
+	r := rand.New(rand.NewSource(21))
+	for i := 0; i < 10000; i++ {
+		k := uint8(r.Intn(100))
+		v := int16(r.Intn(100) - 50)
+		switch r.Intn(4) {
+		case 0:
+			_, kPresent := forward[k]
+			_, vPresent := inverse[v]
+			want := !kPresent && !vPresent
+			if got := m.Insert(k, v); got != want {
+				t.Fatalf("insert (%d, %d) got %t, want %t", k, v, got, want)
+			}
+			if want {
+				forward[k] = v
+				inverse[v] = k
+			}
+		case 1:
+			oldV, ok := forward[k]
+			if got, gotOK := m.Delete(k); got != oldV || gotOK != ok {
+				t.Fatalf("delete %d got (%d, %t), want (%d, %t)", k, got, gotOK, oldV, ok)
+			}
+			if ok {
+				delete(forward, k)
+				delete(inverse, oldV)
+			}
+		default:
+			var want int
+			if oldV, ok := forward[k]; ok && oldV != v {
+				delete(inverse, oldV)
+				delete(forward, k)
+				want++
+			}
+			if oldK, ok := inverse[v]; ok && oldK != k {
+				delete(forward, oldK)
+				delete(inverse, v)
+				want++
+			}
+			forward[k] = v
+			inverse[v] = k
+			if got := m.Put(k, v); got != want {
+				t.Fatalf("put (%d, %d) got %d evictions, want %d", k, v, got, want)
+			}
+		}
+	}
+
+	if got, want := m.Size(), len(forward); got != want {
+		t.Errorf("got size %d, want %d", got, want)
+	}
+	for k, v := range forward {
+		if got, ok := m.Find(k); !ok || got != v {
+			t.Errorf("find %d got (%d, %t), want %d", k, got, ok, v)
+		}
+		if got, ok := m.FindKey(v); !ok || got != k {
+			t.Errorf("find key of %d got (%d, %t), want %d", v, got, ok, k)
+		}
+	}
+
+	var n int
+	last := int16(-1 << 15)
+	for c, ok := m.LeastValue(); ok; ok = c.Ascend() {
+		if c.Key() <= last && n != 0 {
+			t.Errorf("value %d after %d", c.Key(), last)
+		}
+		if forward[c.Value()] != c.Key() {
+			t.Errorf("value %d got key %d", c.Key(), c.Value())
+		}
+		last = c.Key()
+		n++
+	}
+	if n != len(inverse) {
+		t.Errorf("value iteration got %d pairs, want %d", n, len(inverse))
+	}
+
+	for v, k := range inverse {
+		if got, ok := m.DeleteValue(v); !ok || got != k {
+			t.Errorf("delete value %d got (%d, %t), want %d", v, got, ok, k)
+		}
+	}
+	if _, ok := m.Least(); ok {
+		t.Error("got least after delete of all values")
+	}
+}
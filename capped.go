@@ -0,0 +1,121 @@
+package pile
+
+// Eviction selects the Key to remove from a full Capped.
+type Eviction int
+
+// Eviction options
+const (
+	EvictLeast Eviction = iota // remove the least Key first
+	EvictMost                  // remove the greatest Key first
+)
+
+// Capped provides sorted Key–Value registration with a maximum size. Any Key
+// beyond the maximum gets evicted, in Key order. Evicted nodes go back into the
+// Arena of the Map, such that memory stays flat once the maximum is reached.
+// Use NewCapped for instantiation. Do not copy the Capped struct.
+type Capped[Key Sortable, Value any] struct {
+	// OnEvict, when not nil, gets called for each Key–Value pair removed
+	// due to the size limit.
+	OnEvict func(Key, Value)
+
+	m        Map[Key, Value] // includes noCopy
+	size     int
+	max      int
+	eviction Eviction
+}
+
+// NewCapped returns a new Capped with up to max Keys. Max must be 1 or more.
+func NewCapped[Key Sortable, Value any](max int, e Eviction) *Capped[Key, Value] {
+	if max < 1 {
+		panic("pile: Capped size limit below 1")
+	}
+	return &Capped[Key, Value]{max: max, eviction: e}
+}
+
+// Size returns the number of Keys in the Capped.
+func (m *Capped[Key, Value]) Size() int { return m.size }
+
+// Find returns the Value assigned to the Key.
+func (m *Capped[Key, Value]) Find(k Key) (Value, bool) { return m.m.Find(k) }
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (m *Capped[Key, Value]) Update(k Key, v Value) bool { return m.m.Update(k, v) }
+
+// Insert assigns the Value to the Key if and only if the Key is absent. When
+// the Capped is at its maximum size, then one Key gets evicted to make room.
+// The return is false when the Key is present, and when the Key would be the
+// one to evict, in which case the Capped remains as is.
+func (m *Capped[Key, Value]) Insert(k Key, v Value) bool {
+	if m.size >= m.max {
+		// Key to evict
+		var out Key
+		if m.eviction == EvictMost {
+			c, _ := m.m.Most()
+			out = c.Key()
+			if k >= out {
+				return false
+			}
+		} else {
+			c, _ := m.m.Least()
+			out = c.Key()
+			if k <= out {
+				return false
+			}
+		}
+		if !m.m.Insert(k, v) {
+			return false
+		}
+		m.evict(out)
+		return true
+	}
+
+	if !m.m.Insert(k, v) {
+		return false
+	}
+	m.size++
+	return true
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not. When the Capped is at its maximum size, then one Key gets evicted to
+// make room. Put is a no-op when the Key would be the one to evict.
+func (m *Capped[Key, Value]) Put(k Key, v Value) {
+	if !m.m.Update(k, v) {
+		m.Insert(k, v)
+	}
+}
+
+// Delete removes the Key from the Capped, and it returns the Value removed,
+// with false for none. Deletes do not call OnEvict.
+func (m *Capped[Key, Value]) Delete(k Key) (Value, bool) {
+	v, ok := m.m.Delete(k)
+	if ok {
+		m.size--
+	}
+	return v, ok
+}
+
+// Evict removes the Key.
+func (m *Capped[Key, Value]) evict(k Key) {
+	v, _ := m.m.Delete(k)
+	if m.OnEvict != nil {
+		m.OnEvict(k, v)
+	}
+}
+
+// Least returns a new Cursor located at the Key which is less than all others
+// in the Capped. The return is false when Capped is empty. A Delete or Insert
+// renders the Cursor invalid.
+func (m *Capped[Key, Value]) Least() (Cursor[Key, Value], bool) { return m.m.Least() }
+
+// Most returns a new Cursor located at the Key which is more than all others in
+// the Capped. The return is false when Capped is empty. A Delete or Insert
+// renders the Cursor invalid.
+func (m *Capped[Key, Value]) Most() (Cursor[Key, Value], bool) { return m.m.Most() }
+
+// AppendKeys appends each Key in the Capped to dst, ascending in Key order, and
+// it returns the extended buffer.
+func (m *Capped[Key, Value]) AppendKeys(dst []Key) []Key { return m.m.AppendKeys(dst) }
+
+// Stats returns the current state of the underlying Map.
+func (m *Capped[Key, Value]) Stats() Stats { return m.m.Stats() }
@@ -0,0 +1,118 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleCapped() {
+	latest := pile.NewCapped[int64, string](3, pile.EvictLeast)
+	latest.OnEvict = func(t int64, s string) {
+		fmt.Println("evict", t, s)
+	}
+	latest.Put(1700000100, "a")
+	latest.Put(1700000200, "b")
+	latest.Put(1700000300, "c")
+	latest.Put(1700000400, "d")
+	fmt.Println(latest.AppendKeys(nil))
+	// Output:
+	// evict 1700000100 a
+	// [1700000200 1700000300 1700000400]
+}
+
+func TestCapped(t *testing.T) {
+	for _, e := range []pile.Eviction{pile.EvictLeast, pile.EvictMost} {
+		m := pile.NewCapped[int, int](100, e)
+		var evicted [][2]int
+		m.OnEvict = func(k, v int) {
+			evicted = append(evicted, [2]int{k, v})
+		}
+
+		// reference model in ascending order
+		var retained []int
+		var wantEvicted [][2]int
+
+		r := rand.New(rand.NewSource(int64(e)))
+		for i := 0; i < 10000; i++ {
+			k := r.Intn(1 << 20)
+			at := sort.SearchInts(retained, k)
+			present := at < len(retained) && retained[at] == k
+			full := len(retained) == 100
+			// Keys which would be evicted right away are rejected.
+			rejected := full && (e == pile.EvictLeast && at == 0 || e == pile.EvictMost && at == len(retained))
+			if got := m.Insert(k, -k); got != (!present && !rejected) {
+				t.Fatalf("eviction %d: insert %d got %t with presence %t and rejection %t", e, k, got, present, rejected)
+			}
+			if present || rejected {
+				continue
+			}
+
+			retained = append(retained, 0)
+			copy(retained[at+1:], retained[at:])
+			retained[at] = k
+			if len(retained) > 100 {
+				var out int
+				if e == pile.EvictMost {
+					out = retained[len(retained)-1]
+					retained = retained[:len(retained)-1]
+				} else {
+					out = retained[0]
+					retained = retained[1:]
+				}
+				wantEvicted = append(wantEvicted, [2]int{out, -out})
+			}
+		}
+
+		if got := m.Size(); got != 100 {
+			t.Errorf("eviction %d: got size %d, want 100", e, got)
+		}
+		if got := m.AppendKeys(nil); fmt.Sprint(got) != fmt.Sprint(retained) {
+			t.Errorf("eviction %d: got keys %d\nwant %d", e, got, retained)
+		}
+		if fmt.Sprint(evicted) != fmt.Sprint(wantEvicted) {
+			t.Errorf("eviction %d: got OnEvict sequence %d\nwant %d", e, evicted, wantEvicted)
+		}
+
+		// memory stays flat
+		s := m.Stats()
+		nodeN := s.NodeN + s.SpareNodeN
+		for i := 0; i < 10000; i++ {
+			k := r.Intn(1 << 20)
+			m.Insert(k, -k)
+		}
+		s = m.Stats()
+		if got := s.NodeN + s.SpareNodeN; got != nodeN {
+			t.Errorf("eviction %d: node count went from %d to %d", e, nodeN, got)
+		}
+	}
+
+	// rejection of the Key evicted right away
+	m := pile.NewCapped[int, int](1, pile.EvictLeast)
+	m.OnEvict = func(k, v int) {
+		t.Errorf("evicted key %d with value %d", k, v)
+	}
+	m.Insert(5, 5)
+	if m.Insert(1, 1) {
+		t.Error("insert below the least key of a full EvictLeast got true")
+	}
+	m.Put(1, 1)
+	if got := m.AppendKeys(nil); len(got) != 1 || got[0] != 5 {
+		t.Errorf("got keys %d, want [5]", got)
+	}
+}
+
+func TestCappedAllocs(t *testing.T) {
+	m := pile.NewCapped[int, int](1000, pile.EvictLeast)
+	var k int
+	allocs := testing.AllocsPerRun(10000, func() {
+		k++
+		m.Put(k, k)
+	})
+	if allocs != 0 {
+		t.Errorf("got %f allocations per Put with eviction", allocs)
+	}
+}
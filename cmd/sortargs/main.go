@@ -50,7 +50,7 @@ func main() {
 
 	// print
 	if reverse {
-		for c, ok := args.Least(); ok; ok = c.Descend() {
+		for c, ok := args.Most(); ok; ok = c.Descend() {
 			print(c)
 		}
 	} else {
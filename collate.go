@@ -0,0 +1,264 @@
+package pile
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Collation maps a string to its sort key. Strings with an equal sort key are
+// considered equal.
+type Collation func(string) string
+
+// FoldCase orders strings regardless of letter case. Strings which differ in
+// letter case only are equal. Letters fold with the simple case folding from
+// Unicode, such that "σ" and "ς" are equal, plus "ß" to "ss" for German. Other
+// foldings which expand to multiple letters are not applied.
+func FoldCase(s string) string {
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for _, r := range s {
+		r = foldRune(r)
+		if r == 'ß' {
+			buf.WriteString("ss")
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// FoldRune returns the lower case of the least code point with an equal simple
+// case folding.
+func foldRune(r rune) rune {
+	least := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < least {
+			least = f
+		}
+	}
+	return unicode.ToLower(least)
+}
+
+// NaturalOrder orders strings with the numbers therein on their numeric value,
+// such that "file2" goes before "file10". Numbers are runs of ASCII digits.
+// Leading zeros only count when the strings are equal otherwise. Numbers go
+// relative to other characters as digits do in byte order, i.e., after space
+// and most punctuation, and before letters.
+func NaturalOrder(s string) string {
+	var buf strings.Builder
+	buf.Grow(len(s) + len(s)/2 + 1)
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			buf.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		// skip leading zeros
+		for i+1 < len(s) && s[i] == '0' && isDigit(s[i+1]) {
+			i++
+		}
+		end := i
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		// A digit count prefix makes longer numbers go last. The
+		// prefix is a digit, from '0' for 1 digit up to '8' for 9
+		// digits, or '9' followed by the size of the digit count
+		// minus 10 in bytes, and then that count in big-endian.
+		n := end - i
+		if n < 10 {
+			buf.WriteByte(byte('0' + n - 1))
+		} else {
+			count := uint64(n - 10)
+			size := 1
+			for size < 8 && count>>(8*size) != 0 {
+				size++
+			}
+			buf.WriteByte('9')
+			buf.WriteByte(byte(size))
+			for j := size - 1; j >= 0; j-- {
+				buf.WriteByte(byte(count >> (8 * j)))
+			}
+		}
+		buf.WriteString(s[i:end])
+		i = end
+	}
+	// tie-break on the original
+	buf.WriteByte(0)
+	buf.WriteString(s)
+	return buf.String()
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// UnicodeOrder orders strings on their letters first, regardless of accents or
+// letter case. Accents come second, and letter case comes last, with lower case
+// first. The order does not depend on any locale. Letters compare on their code
+// point otherwise, as there is no alphabet across scripts.
+//
+// Canonically equivalent strings are equal, such as an "é" precomposed, and an
+// "e" followed by a combining acute accent. Strings compare in their canonical
+// decomposition (NFD), which covers all of Unicode, Hangul syllables included.
+func UnicodeOrder(s string) string {
+	d := norm.NFD.String(s)
+
+	var buf strings.Builder
+	buf.Grow(4*len(d) + 3)
+
+	// primary level: base letters
+	for _, r := range d {
+		if unicode.Is(unicode.Mn, r) {
+			continue // accent
+		}
+		r = foldRune(r)
+		if base, ok := latinLetters[r]; ok {
+			buf.WriteString(base)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte(0)
+
+	// secondary level: accents, with a marker byte per base character
+	for _, r := range d {
+		if unicode.Is(unicode.Mn, r) {
+			buf.WriteRune(r)
+		} else {
+			buf.WriteByte(1)
+		}
+	}
+	buf.WriteByte(0)
+
+	// tertiary level: letter case, with lower case first
+	for _, r := range d {
+		if unicode.IsUpper(r) {
+			buf.WriteByte('1')
+		} else {
+			buf.WriteByte('0')
+		}
+	}
+	buf.WriteByte(0)
+
+	// tie-break on the canonical form
+	buf.WriteString(d)
+	return buf.String()
+}
+
+// LatinLetters has the base letters of case-folded letters without a canonical
+// decomposition from the Latin-1 Supplement and Latin Extended-A blocks.
+var latinLetters = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'ð': "d", 'ø': "o", 'þ': "th", 'đ': "d", 'ħ': "h",
+	'ı': "i", 'ĳ': "ij", 'ĸ': "k", 'ŀ': "l", 'ł': "l", 'ŉ': "n", 'ŋ': "n",
+	'œ': "oe",
+}
+
+// CollatedMap provides Key–Value registration on strings, ordered by a
+// Collation. Keys with an equal collation are one and the same. Iteration
+// returns the Keys in their original spelling. The zero CollatedMap orders on
+// byte content, like Map does. Do not copy the CollatedMap struct.
+type CollatedMap[Value any] struct {
+	check noCopy
+
+	collate Collation
+	m       Map[string, collated[Value]] // indexed by sort key
+}
+
+// Collated is a CollatedMap entry.
+type collated[Value any] struct {
+	key string // original spelling
+	v   Value
+}
+
+// NewCollatedMap returns a new CollatedMap with the Collation in use.
+func NewCollatedMap[Value any](c Collation) *CollatedMap[Value] {
+	return &CollatedMap[Value]{collate: c}
+}
+
+func (m *CollatedMap[Value]) sortKey(k string) string {
+	if m.collate == nil {
+		return k
+	}
+	return m.collate(k)
+}
+
+// Size returns the number of Keys in the CollatedMap.
+func (m *CollatedMap[Value]) Size() int { return m.m.Size() }
+
+// Find returns the Value assigned to the Key, plus the Key in the spelling of
+// its registration.
+func (m *CollatedMap[Value]) Find(k string) (spelling string, v Value, ok bool) {
+	e, ok := m.m.Find(m.sortKey(k))
+	return e.key, e.v, ok
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (m *CollatedMap[Value]) Insert(k string, v Value) bool {
+	return m.m.Insert(m.sortKey(k), collated[Value]{key: k, v: v})
+}
+
+// Update assigns the Value to the Key if and only if the Key is present. The
+// spelling of the Key registration is retained.
+func (m *CollatedMap[Value]) Update(k string, v Value) bool {
+	p := m.m.FindPointer(m.sortKey(k))
+	if p == nil {
+		return false
+	}
+	p.v = v
+	return true
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or not.
+// The Key registration gets the spelling of k.
+func (m *CollatedMap[Value]) Put(k string, v Value) {
+	m.m.Put(m.sortKey(k), collated[Value]{key: k, v: v})
+}
+
+// Delete removes the Key from the CollatedMap, and it returns the Value removed,
+// with false for none.
+func (m *CollatedMap[Value]) Delete(k string) (Value, bool) {
+	e, ok := m.m.Delete(m.sortKey(k))
+	return e.v, ok
+}
+
+// Least returns a new CollatedCursor located at the Key which goes before all
+// others in the CollatedMap. The return is false when CollatedMap is empty. A
+// Delete or Insert renders the CollatedCursor invalid.
+func (m *CollatedMap[Value]) Least() (CollatedCursor[Value], bool) {
+	c, ok := m.m.Least()
+	return CollatedCursor[Value]{c}, ok
+}
+
+// Most returns a new CollatedCursor located at the Key which goes after all
+// others in the CollatedMap. The return is false when CollatedMap is empty. A
+// Delete or Insert renders the CollatedCursor invalid.
+func (m *CollatedMap[Value]) Most() (CollatedCursor[Value], bool) {
+	c, ok := m.m.Most()
+	return CollatedCursor[Value]{c}, ok
+}
+
+// CollatedCursor navigates over the Keys of a CollatedMap in collation order.
+// Any use of an invalid CollatedCursor panics.
+type CollatedCursor[Value any] struct {
+	c Cursor[string, collated[Value]]
+}
+
+// Key returns the Key at the current position, in its original spelling.
+func (c *CollatedCursor[Value]) Key() string {
+	e := c.c.Value()
+	return e.key
+}
+
+// Value returns the Value at the current position.
+func (c *CollatedCursor[Value]) Value() Value {
+	e := c.c.Value()
+	return e.v
+}
+
+// Ascend moves the CollatedCursor one Key closer to Most, up to Most itself.
+func (c *CollatedCursor[Value]) Ascend() bool { return c.c.Ascend() }
+
+// Descend moves the CollatedCursor one Key closer to Least, up to Least itself.
+func (c *CollatedCursor[Value]) Descend() bool { return c.c.Descend() }
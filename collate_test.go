@@ -0,0 +1,136 @@
+package pile_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleCollatedMap() {
+	files := pile.NewCollatedMap[int](pile.NaturalOrder)
+	for i, name := range []string{"img12.png", "img10.png", "img2.png", "img1.png"} {
+		files.Put(name, i)
+	}
+	for c, ok := files.Least(); ok; ok = c.Ascend() {
+		fmt.Println(c.Key())
+	}
+	// Output:
+	// img1.png
+	// img2.png
+	// img10.png
+	// img12.png
+}
+
+func TestCollationOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		collate pile.Collation
+		ordered []string
+	}{
+		{"FoldCase", pile.FoldCase, []string{"apple", "Banana", "cherry", "Date", "Straße", "Zürich", "Σοφία"}},
+		{"NaturalOrder", pile.NaturalOrder, []string{"", "0", "a b", "a.b", "a1", "a9", "a10", "a:", "x", "x01", "x1", "x2", "x10", "x10a", "x10b", "x0011", "xa", "y"}},
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"cote", "Cote", "coté", "Coté", "côte", "Côte", "côté", "Côté", "cotes", "Ökonomie", "Straße", "Strasse2", "zebra", "Zürich", "αβ", "Αβ", "άβ", "Άβ", "β", "мир", "Мир", "яблоко"}},
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"e", "\u1ec7", "f", "\u01d0", "j"}},
+		// digit runs beyond one byte of count
+		{"NaturalOrder", pile.NaturalOrder, []string{
+			strings.Repeat("9", 264),
+			"1" + strings.Repeat("0", 264),
+			strings.Repeat("9", 265),
+			"1" + strings.Repeat("0", 265),
+			strings.Repeat("9", 1000),
+			"x" + strings.Repeat("0", 2000) + "1",
+		}},
+	}
+	for _, test := range tests {
+		m := pile.NewCollatedMap[int](test.collate)
+		for i := len(test.ordered) - 1; i >= 0; i-- {
+			if !m.Insert(test.ordered[i], i) {
+				t.Errorf("%s: insert %q got false", test.name, test.ordered[i])
+			}
+		}
+		var got []string
+		for c, ok := m.Least(); ok; ok = c.Ascend() {
+			got = append(got, c.Key())
+		}
+		if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", test.ordered) {
+			t.Errorf("%s got order %q\nwant %q", test.name, got, test.ordered)
+		}
+	}
+}
+
+func TestCollatedMapSpelling(t *testing.T) {
+	m := pile.NewCollatedMap[int](pile.FoldCase)
+	m.Put("Hello", 1)
+	if m.Insert("HELLO", 2) {
+		t.Error("insert with other letter case got true")
+	}
+	if !m.Update("hello", 3) {
+		t.Error("update with other letter case got false")
+	}
+	if k, v, ok := m.Find("hELLO"); !ok || k != "Hello" || v != 3 {
+		t.Errorf("find got (%q, %d, %t), want (\"Hello\", 3, true)", k, v, ok)
+	}
+	m.Put("HeLLo", 4)
+	if k, v, _ := m.Find("hello"); k != "HeLLo" || v != 4 {
+		t.Errorf("find after put got (%q, %d), want (\"HeLLo\", 4)", k, v)
+	}
+	if v, ok := m.Delete("HELLO"); !ok || v != 4 {
+		t.Errorf("delete got (%d, %t), want (4, true)", v, ok)
+	}
+	if m.Size() != 0 {
+		t.Errorf("got size %d after delete", m.Size())
+	}
+
+	var zero pile.CollatedMap[int]
+	zero.Put("b", 1)
+	zero.Put("B", 2)
+	if c, _ := zero.Least(); c.Key() != "B" {
+		t.Errorf("zero CollatedMap got least %q, want byte order", c.Key())
+	}
+}
+
+func TestCollationEquivalence(t *testing.T) {
+	tests := []struct {
+		name    string
+		collate pile.Collation
+		equal   []string
+	}{
+		{"FoldCase", pile.FoldCase, []string{"straße", "STRASSE", "Strasse", "STRAẞE"}},
+		{"FoldCase", pile.FoldCase, []string{"σ", "ς", "Σ"}},
+		{"FoldCase", pile.FoldCase, []string{"k", "K", "\u212a"}}, // Kelvin sign
+		// precomposed and decomposed
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"caf\u00e9", "cafe\u0301"}},
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"\u00c5ngstr\u00f6m", "A\u030angstro\u0308m"}},
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"\u03ac", "\u03b1\u0301"}}, // Greek
+		// combining marks in canonical order
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"a\u0323\u0302", "a\u0302\u0323"}},
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"d\u0323\u0307", "d\u0307\u0323"}},
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"\u1ec7", "e\u0323\u0302", "e\u0302\u0323", "\u1eb9\u0302"}},
+		// beyond Latin and Greek
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"\u0439", "\u0438\u0306"}},       // Cyrillic
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"\u01d0", "i\u030c"}},            // Latin Extended-B
+		{"UnicodeOrder", pile.UnicodeOrder, []string{"\ud55c", "\u1112\u1161\u11ab"}}, // Hangul
+	}
+	for _, test := range tests {
+		m := pile.NewCollatedMap[int](test.collate)
+		m.Put(test.equal[0], 0)
+		for i, s := range test.equal[1:] {
+			if m.Insert(s, i+1) {
+				t.Errorf("%s: insert %q got true, want equal to %q", test.name, s, test.equal[0])
+			}
+		}
+		if m.Size() != 1 {
+			t.Errorf("%s: got size %d for %q", test.name, m.Size(), test.equal)
+		}
+	}
+
+	distinct := []string{"cafe", "Cafe", "caf\u00e9", "\u03b1", "\u03ac", "\u0430", "\u0410"}
+	m := pile.NewCollatedMap[int](pile.UnicodeOrder)
+	for i, s := range distinct {
+		if !m.Insert(s, i) {
+			t.Errorf("UnicodeOrder: insert %q got false", s)
+		}
+	}
+}
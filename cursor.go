@@ -21,7 +21,7 @@ func (m *Map[Key, Value]) Least() (Cursor[Key, Value], bool) {
 	for t.subs[0] != nil {
 		t = t.subs[0]
 	}
-	return Cursor[Key, Value]{t: t, pairI: 0}, true
+	return Cursor[Key, Value]{m: m, modN: m.modN, t: t, pairI: 0}, true
 }
 
 // Most returns a new Cursor located at the Key which is more than all others in
@@ -35,22 +35,35 @@ func (m *Map[Key, Value]) Most() (Cursor[Key, Value], bool) {
 	for t.subs[t.pairN&3] != nil {
 		t = t.subs[t.pairN&3]
 	}
-	return Cursor[Key, Value]{t: t, pairI: t.pairN - 1}, true
+	return Cursor[Key, Value]{m: m, modN: m.modN, t: t, pairI: t.pairN - 1}, true
 }
 
-// Cursor navigates over Sortable content.
+// Cursor navigates over Sortable content. A Delete or Insert on the Map renders
+// the Cursor invalid. Any use of an invalid Cursor panics. Updates, including
+// Put on a present Key, do not affect the Cursor.
 type Cursor[Key Sortable, Value any] struct {
+	m    *Map[Key, Value]
+	modN uint64 // structural modification count of m at creation
+
 	t     *node[Key, Value]
 	pairI int
 }
 
+// Check panics when the Map had structural changes since the Cursor creation.
+func (c *Cursor[Key, Value]) check() {
+	if c.modN != c.m.modN {
+		panic("pile: Cursor used after Insert or Delete on Map")
+	}
+}
+
 // Key returns the Key at the current position.
 func (c *Cursor[Key, Value]) Key() Key {
 	if c.t == nil {
 		var zero Key
 		return zero
 	}
-	return c.t.pairs[c.pairI%3].K
+	c.check()
+	return c.t.keys[c.pairI%3]
 }
 
 // Value returns the Value at the current position.
@@ -59,7 +72,8 @@ func (c *Cursor[Key, Value]) Value() Value {
 		var zero Value
 		return zero
 	}
-	return c.t.pairs[c.pairI%3].V
+	c.check()
+	return c.t.values[c.pairI%3]
 }
 
 // Swap sets the Value and it returns the previous one.
@@ -68,9 +82,11 @@ func (c *Cursor[Key, Value]) Swap(v Value) (previous Value) {
 		var zero Value
 		return zero
 	}
-	p := &c.t.pairs[c.pairI%3].V
+	c.check()
+	p := &c.t.values[c.pairI%3]
 	previous = *p
 	*p = v
+	c.m.fixUp(c.t)
 	return
 }
 
@@ -79,6 +95,7 @@ func (c *Cursor[Key, Value]) Ascend() bool {
 	if c.t == nil {
 		return false
 	}
+	c.check()
 	sub := c.t.subs[(c.pairI+1)&3]
 	if sub != nil {
 		// down to bottom level, left side
@@ -128,6 +145,7 @@ func (c *Cursor[Key, Value]) Descend() bool {
 	if c.t == nil {
 		return false
 	}
+	c.check()
 	sub := c.t.subs[c.pairI&3]
 	if sub != nil {
 		// down to bottom level, right side
@@ -165,3 +183,27 @@ func (c *Cursor[Key, Value]) Descend() bool {
 	}
 	return false
 }
+
+// Seek moves the Cursor to the Key, with false for none. The Cursor remains at
+// its position when the Key is absent. Seek climbs up only as far as needed, so
+// the cost grows with the distance to the Key, rather than with the Map size.
+func (c *Cursor[Key, Value]) Seek(k Key) bool {
+	if c.t == nil {
+		return false
+	}
+	c.check()
+
+	// move up until k is in range
+	t := c.t
+	for t.above != nil && (k < t.keys[0] || k > t.keys[t.pairN-1]) {
+		t = t.above
+	}
+
+	t, pairI := t.locate(k)
+	if t == nil {
+		return false
+	}
+	c.t = t
+	c.pairI = pairI
+	return true
+}
@@ -76,6 +76,47 @@ func TestIteration(t *testing.T) {
 	})
 }
 
+func TestSeek(t *testing.T) {
+	var keys pile.Set[int]
+	var want []int
+	for i := 0; i < 999; i++ {
+		keys.Insert(i * 2)
+		want = append(want, i*2)
+	}
+
+	allocN := testing.AllocsPerRun(1, func() {
+		for _, step := range []int{1, 2, 3, 7, 50, 333} {
+			c, _ := keys.Least()
+			for i := step; i < len(want); i += step {
+				if !c.Seek(want[i]) {
+					t.Fatalf("seek key %d with step %d got false", want[i], step)
+				}
+				if got := c.Key(); got != want[i] {
+					t.Fatalf("seek key %d with step %d got key %d", want[i], step, got)
+				}
+			}
+			for i := len(want) - 1; i >= 0; i -= step {
+				if !c.Seek(want[i]) || c.Key() != want[i] {
+					t.Fatalf("seek back to key %d with step %d failed", want[i], step)
+				}
+			}
+		}
+	})
+	if !t.Failed() && allocN != 0 {
+		t.Errorf("seek allocated %f times, want 0", allocN)
+	}
+
+	c, _ := keys.At(500)
+	for _, k := range []int{-1, 501, 1999, 9999} {
+		if c.Seek(k) {
+			t.Errorf("seek absent key %d got true", k)
+		}
+		if got := c.Key(); got != 500 {
+			t.Errorf("seek absent key %d moved cursor to %d", k, got)
+		}
+	}
+}
+
 // VerifyForward iterates ascending to validate keys.
 func verifyForward(t *testing.T, got *pile.Set[int], want []int) {
 	c, ok := got.Least()
@@ -179,6 +220,9 @@ func verifyZeroCursor(t *testing.T, c *pile.Cursor[string, string]) {
 	if c.Descend() {
 		t.Error("got descend from zero iterator")
 	}
+	if c.Seek("x") {
+		t.Error("got seek from zero iterator")
+	}
 	if got := c.Swap("foo"); got != "" {
 		t.Errorf("got %q from swap on zero iterator, want none", got)
 	}
@@ -186,3 +230,102 @@ func verifyZeroCursor(t *testing.T, c *pile.Cursor[string, string]) {
 		t.Errorf("got %q from second swap on zero iterator, want none", got)
 	}
 }
+
+func TestCursorInvalidation(t *testing.T) {
+	var m pile.Map[int, string]
+	m.Put(1, "one")
+	m.Put(2, "two")
+
+	c, ok := m.At(1)
+	if !ok {
+		t.Fatal("key 1 not found")
+	}
+	m.Update(2, "deux")
+	m.Put(1, "un")
+	if m.Insert(2, "zwei") {
+		t.Fatal("insert on present key got true")
+	}
+	if got := c.Value(); got != "un" {
+		t.Errorf("got value %q after updates, want %q", got, "un")
+	}
+	if !c.Ascend() || c.Key() != 2 {
+		t.Error("ascend after updates did not reach key 2")
+	}
+
+	m.Put(3, "three")
+	verifyPanic(t, "Key after Put", func() { c.Key() })
+	verifyPanic(t, "Value after Put", func() { c.Value() })
+	verifyPanic(t, "Swap after Put", func() { c.Swap("x") })
+	verifyPanic(t, "Ascend after Put", func() { c.Ascend() })
+	verifyPanic(t, "Descend after Put", func() { c.Descend() })
+
+	c, _ = m.Least()
+	m.Insert(0, "zero")
+	verifyPanic(t, "Key after Insert", func() { c.Key() })
+}
+
+func verifyPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+	f()
+}
+
+func TestCeil(t *testing.T) {
+	var m pile.Map[int, int]
+	for k := 10; k <= 1000; k += 10 {
+		m.Insert(k, -k)
+	}
+	for k := -5; k <= 1005; k++ {
+		c, ok := m.Ceil(k)
+		want := (k + 9) / 10 * 10
+		if k <= 0 {
+			want = 10
+		}
+		if want > 1000 {
+			if ok {
+				t.Errorf("ceil %d got key %d, want none", k, c.Key())
+			}
+			continue
+		}
+		if !ok || c.Key() != want || c.Value() != -want {
+			t.Errorf("ceil %d got (%d, %t), want key %d", k, c.Key(), ok, want)
+		}
+	}
+
+	var empty pile.Map[int, int]
+	if _, ok := empty.Ceil(1); ok {
+		t.Error("ceil on empty Map got true")
+	}
+}
+
+func TestFloor(t *testing.T) {
+	var m pile.Map[int, int]
+	for k := 10; k <= 1000; k += 10 {
+		m.Insert(k, -k)
+	}
+	for k := -5; k <= 1005; k++ {
+		c, ok := m.Floor(k)
+		want := k / 10 * 10
+		if want > 1000 {
+			want = 1000
+		}
+		if k < 10 {
+			if ok {
+				t.Errorf("floor %d got key %d, want none", k, c.Key())
+			}
+			continue
+		}
+		if !ok || c.Key() != want || c.Value() != -want {
+			t.Errorf("floor %d got (%d, %t), want key %d", k, c.Key(), ok, want)
+		}
+	}
+
+	var empty pile.Map[int, int]
+	if _, ok := empty.Floor(1); ok {
+		t.Error("floor on empty Map got true")
+	}
+}
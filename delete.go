@@ -0,0 +1,156 @@
+package pile
+
+// Delete removes the Key from the Map, and it returns the Value removed, with
+// false for none. Nodes which become redundant go back into the Arena for reuse.
+func (m *Map[Key, Value]) Delete(k Key) (Value, bool) {
+	t, pairI := m.top.locate(k) // nil safe
+	if t == nil {
+		var zero Value
+		return zero, false
+	}
+	m.modN++
+	v := t.values[pairI]
+
+	if t.subs[0] != nil {
+		// replace with the previous pair, from ground level
+		ground := t.subs[pairI]
+		for ground.subs[0] != nil {
+			ground = ground.subs[ground.pairN]
+		}
+		t.keys[pairI] = ground.keys[ground.pairN-1]
+		t.values[pairI] = ground.values[ground.pairN-1]
+		t, pairI = ground, ground.pairN-1
+	}
+
+	removePair(t, pairI, pairI+1)
+	if t.pairN == 0 {
+		m.fixEmpty(t)
+	} else {
+		m.fixUp(t)
+	}
+	return v, true
+}
+
+// RemovePair takes out the pair at pairI plus the subnode at subI. Subnode
+// index subI must be either pairI or pairI + 1.
+func removePair[Key Sortable, Value any](t *node[Key, Value], pairI, subI int) {
+	copy(t.keys[pairI:t.pairN], t.keys[pairI+1:t.pairN])
+	copy(t.values[pairI:t.pairN], t.values[pairI+1:t.pairN])
+	copy(t.subs[subI:t.pairN+1], t.subs[subI+1:t.pairN+1])
+	t.pairN--
+
+	// release any references
+	var zeroKey Key
+	var zeroValue Value
+	t.keys[t.pairN] = zeroKey
+	t.values[t.pairN] = zeroValue
+	t.subs[t.pairN+1] = nil
+}
+
+// FixEmpty restores the B-tree after t lost its last pair. The operation either
+// borrows a pair from a neighbour, or it merges t into a neighbour, which may
+// cause the node above to become empty in turn.
+func (m *Map[Key, Value]) fixEmpty(t *node[Key, Value]) {
+	for {
+		above := t.above
+		if above == nil {
+			// top shrinks one level
+			m.top = t.subs[0]
+			if m.top != nil {
+				m.top.above = nil
+			}
+			m.recycleNode(t)
+			return
+		}
+
+		subI := 0
+		for above.subs[subI] != t {
+			subI++
+		}
+
+		if subI > 0 && above.subs[subI-1].pairN > 1 {
+			// rotate right from left neighbour
+			left := above.subs[subI-1]
+			t.keys[0] = above.keys[subI-1]
+			t.values[0] = above.values[subI-1]
+			t.subs[1] = t.subs[0]
+			t.subs[0] = left.subs[left.pairN]
+			if t.subs[0] != nil {
+				t.subs[0].above = t
+			}
+			t.pairN = 1
+			above.keys[subI-1] = left.keys[left.pairN-1]
+			above.values[subI-1] = left.values[left.pairN-1]
+			removePair(left, left.pairN-1, left.pairN)
+			if m.fix != nil {
+				m.fix(left)
+				m.fix(t)
+				m.fixUp(above)
+			}
+			return
+		}
+
+		if subI < above.pairN && above.subs[subI+1].pairN > 1 {
+			// rotate left from right neighbour
+			right := above.subs[subI+1]
+			t.keys[0] = above.keys[subI]
+			t.values[0] = above.values[subI]
+			t.subs[1] = right.subs[0]
+			if t.subs[1] != nil {
+				t.subs[1].above = t
+			}
+			t.pairN = 1
+			above.keys[subI] = right.keys[0]
+			above.values[subI] = right.values[0]
+			removePair(right, 0, 0)
+			if m.fix != nil {
+				m.fix(right)
+				m.fix(t)
+				m.fixUp(above)
+			}
+			return
+		}
+
+		// neighbour has one pair only
+		if subI > 0 {
+			// merge into left neighbour
+			left := above.subs[subI-1]
+			left.keys[1] = above.keys[subI-1]
+			left.values[1] = above.values[subI-1]
+			left.subs[2] = t.subs[0]
+			if left.subs[2] != nil {
+				left.subs[2].above = left
+			}
+			left.pairN = 2
+			removePair(above, subI-1, subI)
+			if m.fix != nil {
+				m.fix(left)
+			}
+		} else {
+			// merge into right neighbour
+			right := above.subs[1]
+			right.keys[1] = right.keys[0]
+			right.values[1] = right.values[0]
+			right.keys[0] = above.keys[0]
+			right.values[0] = above.values[0]
+			right.subs[2] = right.subs[1]
+			right.subs[1] = right.subs[0]
+			right.subs[0] = t.subs[0]
+			if right.subs[0] != nil {
+				right.subs[0].above = right
+			}
+			right.pairN = 2
+			removePair(above, 0, 0)
+			if m.fix != nil {
+				m.fix(right)
+			}
+		}
+		m.recycleNode(t)
+
+		if above.pairN != 0 {
+			m.fixUp(above)
+			return
+		}
+		t = above
+	}
+}
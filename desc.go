@@ -0,0 +1,117 @@
+package pile
+
+// Desc provides sorted Key–Value registration in descending Key order. Least
+// is the greatest Key, and each Ascend moves to a lesser Key. The zero Desc is
+// empty and ready for use. Do not copy the Desc struct.
+type Desc[Key Sortable, Value any] struct {
+	m Map[Key, Value] // includes noCopy
+}
+
+// Size returns the number of Keys in the Map.
+func (d *Desc[Key, Value]) Size() int { return d.m.Size() }
+
+// Find returns the Value assigned to the Key.
+func (d *Desc[Key, Value]) Find(k Key) (Value, bool) { return d.m.Find(k) }
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (d *Desc[Key, Value]) Insert(k Key, v Value) bool { return d.m.Insert(k, v) }
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (d *Desc[Key, Value]) Update(k Key, v Value) bool { return d.m.Update(k, v) }
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not.
+func (d *Desc[Key, Value]) Put(k Key, v Value) { d.m.Put(k, v) }
+
+// Delete removes the Key from the Map, and it returns the Value removed, with
+// false for none.
+func (d *Desc[Key, Value]) Delete(k Key) (Value, bool) { return d.m.Delete(k) }
+
+// AppendKeys appends each Key in the Map to dst, descending in Key order, and
+// it returns the extended buffer.
+func (d *Desc[Key, Value]) AppendKeys(dst []Key) []Key {
+	for c, ok := d.m.Most(); ok; ok = c.Descend() {
+		dst = append(dst, c.Key())
+	}
+	return dst
+}
+
+// AppendValues appends each Value in the Map to dst, descending in Key order,
+// and it returns the extended buffer.
+func (d *Desc[Key, Value]) AppendValues(dst []Value) []Value {
+	for c, ok := d.m.Most(); ok; ok = c.Descend() {
+		dst = append(dst, c.Value())
+	}
+	return dst
+}
+
+// AppendPairs appends each Key–Value pair in the Map to keys and values,
+// descending in Key order, and it returns the extended buffers.
+func (d *Desc[Key, Value]) AppendPairs(keys []Key, values []Value) ([]Key, []Value) {
+	for c, ok := d.m.Most(); ok; ok = c.Descend() {
+		keys = append(keys, c.Key())
+		values = append(values, c.Value())
+	}
+	return keys, values
+}
+
+// Least returns a new DescCursor located at the greatest Key in the Map. The
+// return is false when Map is empty. A Delete or Insert renders the DescCursor
+// invalid.
+func (d *Desc[Key, Value]) Least() (DescCursor[Key, Value], bool) {
+	c, ok := d.m.Most()
+	return DescCursor[Key, Value]{c}, ok
+}
+
+// Most returns a new DescCursor located at the least Key in the Map. The return
+// is false when Map is empty. A Delete or Insert renders the DescCursor invalid.
+func (d *Desc[Key, Value]) Most() (DescCursor[Key, Value], bool) {
+	c, ok := d.m.Least()
+	return DescCursor[Key, Value]{c}, ok
+}
+
+// At returns a new DescCursor at located the Key, with false for none. A Delete
+// or Insert renders the DescCursor invalid.
+func (d *Desc[Key, Value]) At(k Key) (DescCursor[Key, Value], bool) {
+	c, ok := d.m.At(k)
+	return DescCursor[Key, Value]{c}, ok
+}
+
+// Ceil returns a new DescCursor located at the greatest Key which is not
+// greater than k, i.e., the first one at or after k in descending order, with
+// false for none. A Delete or Insert renders the DescCursor invalid.
+func (d *Desc[Key, Value]) Ceil(k Key) (DescCursor[Key, Value], bool) {
+	c, ok := d.m.Floor(k)
+	return DescCursor[Key, Value]{c}, ok
+}
+
+// Floor returns a new DescCursor located at the least Key which is not less
+// than k, i.e., the last one at or before k in descending order, with false
+// for none. A Delete or Insert renders the DescCursor invalid.
+func (d *Desc[Key, Value]) Floor(k Key) (DescCursor[Key, Value], bool) {
+	c, ok := d.m.Ceil(k)
+	return DescCursor[Key, Value]{c}, ok
+}
+
+// DescCursor navigates over the Keys of a Desc in descending order. Any use of
+// an invalid DescCursor panics.
+type DescCursor[Key Sortable, Value any] struct {
+	c Cursor[Key, Value]
+}
+
+// Key returns the Key at the current position.
+func (c *DescCursor[Key, Value]) Key() Key { return c.c.Key() }
+
+// Value returns the Value at the current position.
+func (c *DescCursor[Key, Value]) Value() Value { return c.c.Value() }
+
+// Swap sets the Value and it returns the previous one.
+func (c *DescCursor[Key, Value]) Swap(v Value) (previous Value) { return c.c.Swap(v) }
+
+// Ascend moves the DescCursor one Key closer to Most, up to Most itself, which
+// is to the next lesser Key.
+func (c *DescCursor[Key, Value]) Ascend() bool { return c.c.Descend() }
+
+// Descend moves the DescCursor one Key closer to Least, up to Least itself,
+// which is to the next greater Key.
+func (c *DescCursor[Key, Value]) Descend() bool { return c.c.Ascend() }
@@ -0,0 +1,67 @@
+package pile_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleDesc() {
+	var newest pile.Desc[int64, string]
+	newest.Put(1700000100, "first")
+	newest.Put(1700000300, "third")
+	newest.Put(1700000200, "second")
+
+	for c, ok := newest.Least(); ok; ok = c.Ascend() {
+		fmt.Println(c.Key(), c.Value())
+	}
+	// Output:
+	// 1700000300 third
+	// 1700000200 second
+	// 1700000100 first
+}
+
+func TestDesc(t *testing.T) {
+	var d pile.Desc[int, int]
+	for k := 10; k <= 100; k += 10 {
+		d.Insert(k, -k)
+	}
+
+	want := "[100 90 80 70 60 50 40 30 20 10]"
+	if got := fmt.Sprint(d.AppendKeys(nil)); got != want {
+		t.Errorf("append keys got %s, want %s", got, want)
+	}
+	keys, values := d.AppendPairs(nil, nil)
+	if fmt.Sprint(keys) != want || len(values) != 10 || values[0] != -100 {
+		t.Errorf("append pairs got %d and %d", keys, values)
+	}
+
+	var got []int
+	c, ok := d.Most()
+	for ; ok; ok = c.Descend() {
+		got = append(got, c.Key())
+	}
+	if fmt.Sprint(got) != "[10 20 30 40 50 60 70 80 90 100]" {
+		t.Errorf("descend from most got %d", got)
+	}
+
+	if c, ok := d.Ceil(55); !ok || c.Key() != 50 {
+		t.Errorf("ceil 55 got (%d, %t), want (50, true)", c.Key(), ok)
+	}
+	if c, ok := d.Floor(55); !ok || c.Key() != 60 {
+		t.Errorf("floor 55 got (%d, %t), want (60, true)", c.Key(), ok)
+	}
+	if _, ok := d.Ceil(5); ok {
+		t.Error("ceil 5 got true")
+	}
+
+	// range [70, 30] in descending order
+	got = got[:0]
+	for c, ok := d.Ceil(75); ok && c.Key() >= 30; ok = c.Ascend() {
+		got = append(got, c.Key())
+	}
+	if fmt.Sprint(got) != "[70 60 50 40 30]" {
+		t.Errorf("range from ceil 75 got %d", got)
+	}
+}
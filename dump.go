@@ -0,0 +1,151 @@
+package pile
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DumpFormat is a notation option for DumpTree.
+type DumpFormat int
+
+// Dump Formats
+const (
+	// DumpText lists the nodes per level, one line per level.
+	DumpText DumpFormat = iota
+
+	// DumpDOT is a Graphviz digraph with record shapes. Subnodes link
+	// from their position in between the pairs of a node.
+	DumpDOT
+)
+
+// DumpTree writes the B-tree structure of the Map in the given notation. The
+// output is limited to levelN levels from the top, with zero for no limit. The
+// Map is written to w in one go, i.e., no partial output on errors.
+func (m *Map[Key, Value]) DumpTree(w io.Writer, format DumpFormat, levelN int) error {
+	var b strings.Builder
+	switch format {
+	case DumpText:
+		m.dumpText(&b, levelN)
+	case DumpDOT:
+		m.dumpDOT(&b, levelN)
+	default:
+		return fmt.Errorf("pile: unknown dump format %d", format)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (m *Map[Key, Value]) dumpText(b *strings.Builder, levelN int) {
+	var height int
+	for t := m.top; t != nil; t = t.subs[0] {
+		height++
+	}
+	if height == 0 {
+		return
+	}
+
+	minLevel := 0
+	if levelN > 0 && height > levelN {
+		minLevel = height - levelN
+	}
+
+	// scan line starts at top
+	nodeRow := []*node[Key, Value]{m.top}
+	for level := height - 1; ; level-- {
+		fmt.Fprintf(b, "Level %d:", level)
+		for i := range nodeRow {
+			b.WriteByte(' ')
+			b.WriteString(nodeRow[i].String())
+		}
+		b.WriteByte('\n')
+
+		if level == minLevel {
+			return
+		}
+
+		var subs []*node[Key, Value]
+		for _, t := range nodeRow {
+			subs = append(subs, t.subs[:t.pairN+1]...)
+		}
+		nodeRow = subs
+	}
+}
+
+func (m *Map[Key, Value]) dumpDOT(b *strings.Builder, levelN int) {
+	b.WriteString("digraph pile {\n\tnode [shape=record];\n")
+
+	// scan line starts at top, with identifiers in level order
+	var nodeRow []*node[Key, Value]
+	if m.top != nil {
+		nodeRow = append(nodeRow, m.top)
+	}
+	nodeN := len(nodeRow)
+	for level := 1; len(nodeRow) != 0; level++ {
+		subsFollow := levelN <= 0 || level < levelN
+		var subs []*node[Key, Value]
+		for i, t := range nodeRow {
+			id := nodeN - len(nodeRow) + i
+
+			fmt.Fprintf(b, "\tn%d [label=\"<s0>", id)
+			for pairI := range t.keys[:t.pairN] {
+				b.WriteByte('|')
+				b.WriteString(dotEscape(fmt.Sprintf("%#v: %#v", t.keys[pairI], t.values[pairI])))
+				fmt.Fprintf(b, "|<s%d>", pairI+1)
+			}
+			b.WriteString("\"];\n")
+
+			if !subsFollow || t.subs[0] == nil {
+				continue
+			}
+			for subI, sub := range t.subs[:t.pairN+1] {
+				fmt.Fprintf(b, "\tn%d:s%d -> n%d;\n", id, subI, nodeN+len(subs))
+				subs = append(subs, sub)
+			}
+		}
+		nodeN += len(subs)
+		nodeRow = subs
+	}
+
+	b.WriteString("}\n")
+}
+
+// DotEscape returns s safe for use in a record label.
+func dotEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '"', '{', '}', '|', '<', '>':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// String returns a compact notation for debugging purposes.
+func (t node[Key, Value]) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	printAsSub(&b, t.subs[0])
+	for i := range t.keys[:t.pairN] {
+		fmt.Fprintf(&b, " %#v:%#v ", t.keys[i], t.values[i])
+		printAsSub(&b, t.subs[i+1])
+	}
+	b.WriteString(" ]")
+	return b.String()
+}
+
+func printAsSub[Key Sortable, Value any](w io.Writer, t *node[Key, Value]) {
+	if t == nil {
+		return
+	}
+	switch t.pairN {
+	case 1:
+		fmt.Fprintf(w, " #%#v", t.keys[0])
+	case 2:
+		fmt.Fprintf(w, " #%#v,%v", t.keys[0], t.keys[1])
+	case 3:
+		fmt.Fprintf(w, " #%#v,%v,%v", t.keys[0], t.keys[1], t.keys[2])
+	}
+}
@@ -0,0 +1,66 @@
+package pile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDumpTree(t *testing.T) {
+	var m Map[string, int]
+	for i, k := range []string{"a", "b", "c", "d", "e|f", "g"} {
+		m.Put(k, i)
+	}
+
+	tests := []struct {
+		format DumpFormat
+		levelN int
+		want   string
+	}{
+		{DumpText, 0, `Level 1: [ #"a",b "c":2  #"d",e|f,g ]
+Level 0: [ "a":0  "b":1  ] [ "d":3  "e|f":4  "g":5  ]
+`},
+		{DumpText, 1, `Level 1: [ #"a",b "c":2  #"d",e|f,g ]
+`},
+		{DumpDOT, 0, `digraph pile {
+	node [shape=record];
+	n0 [label="<s0>|\"c\": 2|<s1>"];
+	n0:s0 -> n1;
+	n0:s1 -> n2;
+	n1 [label="<s0>|\"a\": 0|<s1>|\"b\": 1|<s2>"];
+	n2 [label="<s0>|\"d\": 3|<s1>|\"e\|f\": 4|<s2>|\"g\": 5|<s3>"];
+}
+`},
+		{DumpDOT, 1, `digraph pile {
+	node [shape=record];
+	n0 [label="<s0>|\"c\": 2|<s1>"];
+}
+`},
+	}
+	for _, test := range tests {
+		var b strings.Builder
+		if err := m.DumpTree(&b, test.format, test.levelN); err != nil {
+			t.Errorf("format %d with %d levels got error: %s", test.format, test.levelN, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("format %d with %d levels got:\n%s\nwant:\n%s", test.format, test.levelN, got, test.want)
+		}
+	}
+
+	if err := m.DumpTree(new(strings.Builder), DumpFormat(99), 0); err == nil {
+		t.Error("unknown format got no error")
+	}
+}
+
+func TestDumpTreeEmpty(t *testing.T) {
+	var m Map[int, int]
+	var b strings.Builder
+	if err := m.DumpTree(&b, DumpText, 0); err != nil || b.Len() != 0 {
+		t.Errorf("text on empty Map got %q, %v", b.String(), err)
+	}
+	b.Reset()
+	const want = "digraph pile {\n\tnode [shape=record];\n}\n"
+	if err := m.DumpTree(&b, DumpDOT, 0); err != nil || b.String() != want {
+		t.Errorf("DOT on empty Map got %q, %v; want %q", b.String(), err, want)
+	}
+}
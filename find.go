@@ -3,45 +3,48 @@ package pile
 // FindPointer returns the Value assigned to the Key, with nil for none. The
 // return becomes undefined after any mutation to the Map. Use with caution.
 func (m *Map[Key, Value]) FindPointer(k Key) *Value {
-	t := m.top
+	return m.top.findPointer(k) // nil safe
+}
+
+func (t *node[Key, Value]) findPointer(k Key) *Value {
 	for t != nil {
 		switch t.pairN {
 		case 3:
 			switch {
-			case k > t.pairs[1].K:
+			case k > t.keys[1]:
 				switch {
-				case k > t.pairs[2].K:
+				case k > t.keys[2]:
 					t = t.subs[3]
-				case k < t.pairs[2].K:
+				case k < t.keys[2]:
 					t = t.subs[2]
 				default:
-					return &t.pairs[2].V
+					return &t.values[2]
 				}
-			case k <= t.pairs[0].K:
-				if k < t.pairs[0].K {
+			case k <= t.keys[0]:
+				if k < t.keys[0] {
 					t = t.subs[0]
 				} else {
-					return &t.pairs[0].V
+					return &t.values[0]
 				}
-			case k < t.pairs[1].K:
+			case k < t.keys[1]:
 				t = t.subs[1]
 			default:
-				return &t.pairs[1].V
+				return &t.values[1]
 			}
 
 		case 2:
 			switch {
-			case k >= t.pairs[1].K:
-				if k > t.pairs[1].K {
+			case k >= t.keys[1]:
+				if k > t.keys[1] {
 					t = t.subs[2]
 				} else {
-					return &t.pairs[1].V
+					return &t.values[1]
 				}
-			case k <= t.pairs[0].K:
-				if k < t.pairs[0].K {
+			case k <= t.keys[0]:
+				if k < t.keys[0] {
 					t = t.subs[0]
 				} else {
-					return &t.pairs[0].V
+					return &t.values[0]
 				}
 			default:
 				t = t.subs[1]
@@ -49,12 +52,12 @@ func (m *Map[Key, Value]) FindPointer(k Key) *Value {
 
 		default:
 			switch {
-			case k > t.pairs[0].K:
+			case k > t.keys[0]:
 				t = t.subs[1]
-			case k < t.pairs[0].K:
+			case k < t.keys[0]:
 				t = t.subs[0]
 			default:
-				return &t.pairs[0].V
+				return &t.values[0]
 			}
 		}
 	}
@@ -74,6 +77,16 @@ func (m *Map[Key, Value]) Find(k Key) (Value, bool) {
 
 // Update assigns the Value to the Key if and only if the Key is present.
 func (m *Map[Key, Value]) Update(k Key, v Value) bool {
+	if m.fix != nil {
+		t, pairI := m.top.locate(k) // nil safe
+		if t == nil {
+			return false
+		}
+		t.values[pairI] = v
+		m.fixUp(t)
+		return true
+	}
+
 	vp := m.FindPointer(k)
 	if vp == nil {
 		return false
@@ -85,45 +98,102 @@ func (m *Map[Key, Value]) Update(k Key, v Value) bool {
 // At returns a new Cursor at located the Key, with false for none. A Delete or
 // Insert renders the Cursor invalid.
 func (m *Map[Key, Value]) At(k Key) (Cursor[Key, Value], bool) {
-	t := m.top
+	t, pairI := m.top.locate(k) // nil safe
+	if t == nil {
+		return Cursor[Key, Value]{}, false
+	}
+	return Cursor[Key, Value]{m: m, modN: m.modN, t: t, pairI: pairI}, true
+}
+
+// Ceil returns a new Cursor located at the least Key which is not less than k,
+// with false for none. A Delete or Insert renders the Cursor invalid.
+func (m *Map[Key, Value]) Ceil(k Key) (Cursor[Key, Value], bool) {
+	var match *node[Key, Value]
+	var matchI int
+	for t := m.top; t != nil; {
+		i := 0
+		for i < t.pairN && t.keys[i] < k {
+			i++
+		}
+		if i < t.pairN {
+			if t.keys[i] == k {
+				return Cursor[Key, Value]{m: m, modN: m.modN, t: t, pairI: i}, true
+			}
+			match, matchI = t, i
+		}
+		t = t.subs[i]
+	}
+	if match == nil {
+		return Cursor[Key, Value]{}, false
+	}
+	return Cursor[Key, Value]{m: m, modN: m.modN, t: match, pairI: matchI}, true
+}
+
+// Floor returns a new Cursor located at the greatest Key which is not greater
+// than k, with false for none. A Delete or Insert renders the Cursor invalid.
+func (m *Map[Key, Value]) Floor(k Key) (Cursor[Key, Value], bool) {
+	var match *node[Key, Value]
+	var matchI int
+	for t := m.top; t != nil; {
+		i := 0
+		for i < t.pairN && t.keys[i] <= k {
+			i++
+		}
+		if i > 0 {
+			if t.keys[i-1] == k {
+				return Cursor[Key, Value]{m: m, modN: m.modN, t: t, pairI: i - 1}, true
+			}
+			match, matchI = t, i-1
+		}
+		t = t.subs[i]
+	}
+	if match == nil {
+		return Cursor[Key, Value]{}, false
+	}
+	return Cursor[Key, Value]{m: m, modN: m.modN, t: match, pairI: matchI}, true
+}
+
+// Locate returns the node with the Key in t or below, including its pair index.
+// The node is nil for none.
+func (t *node[Key, Value]) locate(k Key) (*node[Key, Value], int) {
 	for t != nil {
 		switch t.pairN {
 		case 3:
 			switch {
-			case k > t.pairs[1].K:
+			case k > t.keys[1]:
 				switch {
-				case k > t.pairs[2].K:
+				case k > t.keys[2]:
 					t = t.subs[3]
-				case k < t.pairs[2].K:
+				case k < t.keys[2]:
 					t = t.subs[2]
 				default:
-					return Cursor[Key, Value]{t: t, pairI: 2}, true
+					return t, 2
 				}
-			case k <= t.pairs[0].K:
-				if k < t.pairs[0].K {
+			case k <= t.keys[0]:
+				if k < t.keys[0] {
 					t = t.subs[0]
 				} else {
-					return Cursor[Key, Value]{t: t, pairI: 0}, true
+					return t, 0
 				}
-			case k < t.pairs[1].K:
+			case k < t.keys[1]:
 				t = t.subs[1]
 			default:
-				return Cursor[Key, Value]{t: t, pairI: 1}, true
+				return t, 1
 			}
 
 		case 2:
 			switch {
-			case k >= t.pairs[1].K:
-				if k > t.pairs[1].K {
+			case k >= t.keys[1]:
+				if k > t.keys[1] {
 					t = t.subs[2]
 				} else {
-					return Cursor[Key, Value]{t: t, pairI: 1}, true
+					return t, 1
 				}
-			case k <= t.pairs[0].K:
-				if k < t.pairs[0].K {
+			case k <= t.keys[0]:
+				if k < t.keys[0] {
 					t = t.subs[0]
 				} else {
-					return Cursor[Key, Value]{t: t, pairI: 0}, true
+					return t, 0
 				}
 			default:
 				t = t.subs[1]
@@ -131,15 +201,15 @@ func (m *Map[Key, Value]) At(k Key) (Cursor[Key, Value], bool) {
 
 		default:
 			switch {
-			case k > t.pairs[0].K:
+			case k > t.keys[0]:
 				t = t.subs[1]
-			case k < t.pairs[0].K:
+			case k < t.keys[0]:
 				t = t.subs[0]
 			default:
-				return Cursor[Key, Value]{t: t, pairI: 0}, true
+				return t, 0
 			}
 		}
 	}
 
-	return Cursor[Key, Value]{}, false
+	return nil, 0
 }
@@ -0,0 +1,124 @@
+package pile
+
+// IntervalTree provides Value registration on Key intervals, which may overlap.
+// Each interval includes its low bound, and it excludes its high bound, as in
+// [lo, hi). The nodes track the highest bound below, such that queries skip
+// any subtree without a match. The zero IntervalTree is empty and ready for
+// use. Do not copy the IntervalTree struct.
+type IntervalTree[Key Sortable, Value any] struct {
+	check noCopy
+
+	// intervals per low bound, aggregated on high bound
+	tree augMap[Key, []interval[Key, Value], Key]
+	n    int // number of intervals
+}
+
+// Interval is an IntervalTree entry.
+type interval[Key Sortable, Value any] struct {
+	hi Key // exclusive bound
+	v  Value
+}
+
+// Size returns the number of intervals in the IntervalTree.
+func (it *IntervalTree[Key, Value]) Size() int { return it.n }
+
+// Insert adds the Value on interval [lo, hi). Intervals with equal bounds
+// retain the order in which they were inserted. Insert is a no-op when lo is
+// not less than hi.
+func (it *IntervalTree[Key, Value]) Insert(lo, hi Key, v Value) {
+	if !(lo < hi) {
+		return
+	}
+	if it.tree.lift == nil {
+		it.tree.init(highestBound[Key, Value], maxKey[Key])
+	}
+
+	it.n++
+	entry := interval[Key, Value]{hi: hi, v: v}
+	if p, t := it.tree.findPointer(lo); p != nil {
+		*p = append(*p, entry)
+		it.tree.refresh(t)
+	} else {
+		it.tree.insert(lo, []interval[Key, Value]{entry})
+	}
+}
+
+// Delete removes the interval [lo, hi) which was inserted first, and it returns
+// its Value, with false for none.
+func (it *IntervalTree[Key, Value]) Delete(lo, hi Key) (Value, bool) {
+	p, t := it.tree.findPointer(lo)
+	if p != nil {
+		for i, entry := range *p {
+			if entry.hi != hi {
+				continue
+			}
+
+			it.n--
+			if len(*p) == 1 {
+				it.tree.delete(lo)
+			} else {
+				copy((*p)[i:], (*p)[i+1:])
+				(*p)[len(*p)-1] = interval[Key, Value]{} // release any references
+				*p = (*p)[:len(*p)-1]
+				it.tree.refresh(t)
+			}
+			return entry.v, true
+		}
+	}
+	var zero Value
+	return zero, false
+}
+
+func highestBound[Key Sortable, Value any](intervals []interval[Key, Value]) Key {
+	hi := intervals[0].hi
+	for _, entry := range intervals[1:] {
+		if entry.hi > hi {
+			hi = entry.hi
+		}
+	}
+	return hi
+}
+
+func maxKey[Key Sortable](a, b Key) Key {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Containing calls f for each interval which contains the Key, ascending in
+// order of the low bound, until f returns false.
+func (it *IntervalTree[Key, Value]) Containing(k Key, f func(lo, hi Key, v Value) bool) {
+	it.walk(it.tree.m.top, k, k, true, f)
+}
+
+// Overlapping calls f for each interval which has any Keys in common with the
+// interval [lo, hi), ascending in order of the low bound, until f returns false.
+func (it *IntervalTree[Key, Value]) Overlapping(lo, hi Key, f func(lo, hi Key, v Value) bool) {
+	if lo < hi {
+		it.walk(it.tree.m.top, lo, hi, false, f)
+	}
+}
+
+// Walk calls f for each interval in t and below with a high bound greater than
+// lo, and with a low bound less than hi, or equal to hi when inclusive.
+func (it *IntervalTree[Key, Value]) walk(t *node[Key, augValue[[]interval[Key, Value], Key]], lo, hi Key, inclusive bool, f func(lo, hi Key, v Value) bool) bool {
+	if t == nil || aggOf(t) <= lo {
+		return true // no match in subtree
+	}
+	for i := 0; i < t.pairN; i++ {
+		if !it.walk(t.subs[i], lo, hi, inclusive, f) {
+			return false
+		}
+		k := t.keys[i]
+		if k > hi || k == hi && !inclusive {
+			return true // remaining Keys too high
+		}
+		for _, entry := range t.values[i].v {
+			if entry.hi > lo && !f(k, entry.hi, entry.v) {
+				return false
+			}
+		}
+	}
+	return it.walk(t.subs[t.pairN], lo, hi, inclusive, f)
+}
@@ -0,0 +1,131 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleIntervalTree() {
+	var meetings pile.IntervalTree[int, string]
+	meetings.Insert(900, 1000, "standup")
+	meetings.Insert(930, 1100, "review")
+	meetings.Insert(1300, 1400, "planning")
+
+	meetings.Containing(945, func(lo, hi int, name string) bool {
+		fmt.Println(lo, hi, name)
+		return true
+	})
+	// Output:
+	// 900 1000 standup
+	// 930 1100 review
+}
+
+func TestIntervalTree(t *testing.T) {
+	type entry struct{ lo, hi, v int }
+	var reference []entry // in order of insertion
+	var tree pile.IntervalTree[int, int]
+
+	r := rand.New(rand.NewSource(11))
+	for i := 0; i < 2000; i++ {
+		lo := r.Intn(1000)
+		hi := lo + 1 + r.Intn(50)
+		if r.Intn(100) == 0 {
+			hi = lo + 1 + r.Intn(500) // occasional long one
+		}
+		tree.Insert(lo, hi, i)
+		reference = append(reference, entry{lo, hi, i})
+	}
+	tree.Insert(5, 5, -1) // empty; no-op
+
+	verify := func(t *testing.T) {
+		t.Helper()
+		if got, want := tree.Size(), len(reference); got != want {
+			t.Errorf("got size %d, want %d", got, want)
+		}
+
+		// ascending low bound, with insertion order on ties
+		ordered := append([]entry(nil), reference...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].lo < ordered[j].lo
+		})
+
+		for _, bounds := range [][2]int{{0, 1}, {-9, 0}, {500, 510}, {999, 1600}, {250, 251}, {0, 2000}} {
+			var want []entry
+			for _, e := range ordered {
+				if e.lo < bounds[1] && e.hi > bounds[0] {
+					want = append(want, e)
+				}
+			}
+			var got []entry
+			tree.Overlapping(bounds[0], bounds[1], func(lo, hi, v int) bool {
+				got = append(got, entry{lo, hi, v})
+				return true
+			})
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("overlapping [%d, %d) got %v, want %v", bounds[0], bounds[1], got, want)
+			}
+		}
+
+		for x := -1; x < 1100; x += 7 {
+			var want []entry
+			for _, e := range ordered {
+				if e.lo <= x && x < e.hi {
+					want = append(want, e)
+				}
+			}
+			var got []entry
+			tree.Containing(x, func(lo, hi, v int) bool {
+				got = append(got, entry{lo, hi, v})
+				return true
+			})
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("containing %d got %v, want %v", x, got, want)
+			}
+		}
+	}
+	t.Run("Insert", verify)
+
+	for i := 0; i < 1500; i++ {
+		lo := r.Intn(1000)
+		hi := lo + 1 + r.Intn(50)
+		want := -1
+		for j, e := range reference {
+			if e.lo == lo && e.hi == hi {
+				want = e.v
+				reference = append(reference[:j], reference[j+1:]...)
+				break
+			}
+		}
+		v, ok := tree.Delete(lo, hi)
+		if ok != (want >= 0) || ok && v != want {
+			t.Fatalf("delete [%d, %d) got (%d, %t), want %d", lo, hi, v, ok, want)
+		}
+	}
+	// delete the remainder in part, by bounds present
+	for len(reference) > 200 {
+		e := reference[r.Intn(len(reference))]
+		first := 0
+		for reference[first].lo != e.lo || reference[first].hi != e.hi {
+			first++
+		}
+		want := reference[first].v
+		reference = append(reference[:first], reference[first+1:]...)
+		if v, ok := tree.Delete(e.lo, e.hi); !ok || v != want {
+			t.Fatalf("delete [%d, %d) got (%d, %t), want (%d, true)", e.lo, e.hi, v, ok, want)
+		}
+	}
+	t.Run("Delete", verify)
+
+	var n int
+	tree.Overlapping(0, 2000, func(lo, hi, v int) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Errorf("overlapping continued after false; got %d calls", n)
+	}
+}
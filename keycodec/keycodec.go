@@ -0,0 +1,241 @@
+// Package keycodec provides order-preserving encodings. The byte order of each
+// encoding matches the order of the value encoded, which makes the result a
+// Sortable Key when converted to a string. Encodings concatenate, such that a
+// sequence of values sorts on the first value, and then on the next one, and
+// so on.
+//
+// Each Append function has a Decode counterpart which reads the value from the
+// start of a buffer, and which returns the remainder of the buffer. The Desc
+// variants encode in descending order.
+package keycodec
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrTruncated signals an end of input within an encoding.
+var ErrTruncated = errors.New("keycodec: encoding truncated")
+
+// ErrEscape signals a zero byte in a string encoding without a valid escape.
+var ErrEscape = errors.New("keycodec: invalid escape in string encoding")
+
+// String encodings end with a terminator. Zero bytes in the string escape to a
+// zero followed by escapeMark, which sorts after the terminator.
+const (
+	escapeByte = 0x00
+	escapeMark = 0xff
+	endMark    = 0x01
+)
+
+// AppendUint64 appends the big-endian encoding of v to dst, and it returns the
+// extended buffer.
+func AppendUint64(dst []byte, v uint64) []byte {
+	return appendUint64(dst, v, 0)
+}
+
+// AppendUint64Desc appends the encoding of v to dst in descending order, and it
+// returns the extended buffer.
+func AppendUint64Desc(dst []byte, v uint64) []byte {
+	return appendUint64(dst, v, math.MaxUint64)
+}
+
+// DecodeUint64 reads the encoding from AppendUint64, and it returns the buffer
+// remainder.
+func DecodeUint64(src []byte) (uint64, []byte, error) {
+	return decodeUint64(src, 0)
+}
+
+// DecodeUint64Desc reads the encoding from AppendUint64Desc, and it returns the
+// buffer remainder.
+func DecodeUint64Desc(src []byte) (uint64, []byte, error) {
+	return decodeUint64(src, math.MaxUint64)
+}
+
+func appendUint64(dst []byte, v, mask uint64) []byte {
+	v ^= mask
+	return append(dst, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func decodeUint64(src []byte, mask uint64) (uint64, []byte, error) {
+	if len(src) < 8 {
+		return 0, src, ErrTruncated
+	}
+	return binary.BigEndian.Uint64(src) ^ mask, src[8:], nil
+}
+
+// AppendInt64 appends the encoding of v to dst, and it returns the extended
+// buffer. The sign bit flips, such that negative numbers sort first.
+func AppendInt64(dst []byte, v int64) []byte {
+	return appendUint64(dst, uint64(v), 1<<63)
+}
+
+// AppendInt64Desc appends the encoding of v to dst in descending order, and it
+// returns the extended buffer.
+func AppendInt64Desc(dst []byte, v int64) []byte {
+	return appendUint64(dst, uint64(v), math.MaxUint64>>1)
+}
+
+// DecodeInt64 reads the encoding from AppendInt64, and it returns the buffer
+// remainder.
+func DecodeInt64(src []byte) (int64, []byte, error) {
+	v, rest, err := decodeUint64(src, 1<<63)
+	return int64(v), rest, err
+}
+
+// DecodeInt64Desc reads the encoding from AppendInt64Desc, and it returns the
+// buffer remainder.
+func DecodeInt64Desc(src []byte) (int64, []byte, error) {
+	v, rest, err := decodeUint64(src, math.MaxUint64>>1)
+	return int64(v), rest, err
+}
+
+// AppendFloat64 appends the encoding of v to dst, and it returns the extended
+// buffer. Negative zero sorts before positive zero. NaN values with the sign
+// bit set sort before negative infinity, and the others after positive
+// infinity.
+func AppendFloat64(dst []byte, v float64) []byte {
+	return appendUint64(dst, floatOrder(v), 0)
+}
+
+// AppendFloat64Desc appends the encoding of v to dst in descending order, and
+// it returns the extended buffer.
+func AppendFloat64Desc(dst []byte, v float64) []byte {
+	return appendUint64(dst, floatOrder(v), math.MaxUint64)
+}
+
+// DecodeFloat64 reads the encoding from AppendFloat64, and it returns the
+// buffer remainder.
+func DecodeFloat64(src []byte) (float64, []byte, error) {
+	v, rest, err := decodeUint64(src, 0)
+	return floatFromOrder(v), rest, err
+}
+
+// DecodeFloat64Desc reads the encoding from AppendFloat64Desc, and it returns
+// the buffer remainder.
+func DecodeFloat64Desc(src []byte) (float64, []byte, error) {
+	v, rest, err := decodeUint64(src, math.MaxUint64)
+	return floatFromOrder(v), rest, err
+}
+
+// FloatOrder maps the IEEE 754 bits of f such that unsigned order matches the
+// numeric order. Positive numbers get the sign bit set, and negative numbers
+// get all bits inverted.
+func floatOrder(f float64) uint64 {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | 1<<63
+}
+
+func floatFromOrder(v uint64) float64 {
+	if v&(1<<63) != 0 {
+		return math.Float64frombits(v &^ (1 << 63))
+	}
+	return math.Float64frombits(^v)
+}
+
+// AppendString appends the encoding of s to dst, and it returns the extended
+// buffer. Zero bytes get escaped, and the encoding ends with a terminator, such
+// that a string sorts before any of its extensions.
+func AppendString(dst []byte, s string) []byte {
+	return appendString(dst, s, 0)
+}
+
+// AppendStringDesc appends the encoding of s to dst in descending order, and
+// it returns the extended buffer.
+func AppendStringDesc(dst []byte, s string) []byte {
+	return appendString(dst, s, 0xff)
+}
+
+// DecodeString reads the encoding from AppendString, and it returns the buffer
+// remainder.
+func DecodeString(src []byte) (string, []byte, error) {
+	return decodeString(src, 0)
+}
+
+// DecodeStringDesc reads the encoding from AppendStringDesc, and it returns the
+// buffer remainder.
+func DecodeStringDesc(src []byte) (string, []byte, error) {
+	return decodeString(src, 0xff)
+}
+
+func appendString(dst []byte, s string, mask byte) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		dst = append(dst, c^mask)
+		if c == escapeByte {
+			dst = append(dst, escapeMark^mask)
+		}
+	}
+	return append(dst, escapeByte^mask, endMark^mask)
+}
+
+func decodeString(src []byte, mask byte) (string, []byte, error) {
+	buf := make([]byte, 0, len(src))
+	for i := 0; i < len(src); i++ {
+		c := src[i] ^ mask
+		if c != escapeByte {
+			buf = append(buf, c)
+			continue
+		}
+
+		i++
+		if i >= len(src) {
+			break
+		}
+		switch src[i] ^ mask {
+		case escapeMark:
+			buf = append(buf, escapeByte)
+		case endMark:
+			return string(buf), src[i+1:], nil
+		default:
+			return "", src, ErrEscape
+		}
+	}
+	return "", src, ErrTruncated
+}
+
+// AppendTime appends the encoding of t to dst, and it returns the extended
+// buffer. The encoding has nanosecond precision. Neither the location nor the
+// monotonic clock reading is retained.
+func AppendTime(dst []byte, t time.Time) []byte {
+	dst = appendUint64(dst, uint64(t.Unix()), 1<<63)
+	return appendNanos(dst, t.Nanosecond(), 0)
+}
+
+// AppendTimeDesc appends the encoding of t to dst in descending order, and it
+// returns the extended buffer.
+func AppendTimeDesc(dst []byte, t time.Time) []byte {
+	dst = appendUint64(dst, uint64(t.Unix()), math.MaxUint64>>1)
+	return appendNanos(dst, t.Nanosecond(), math.MaxUint32)
+}
+
+// DecodeTime reads the encoding from AppendTime, and it returns the buffer
+// remainder. The return is in UTC.
+func DecodeTime(src []byte) (time.Time, []byte, error) {
+	return decodeTime(src, 1<<63, 0)
+}
+
+// DecodeTimeDesc reads the encoding from AppendTimeDesc, and it returns the
+// buffer remainder. The return is in UTC.
+func DecodeTimeDesc(src []byte) (time.Time, []byte, error) {
+	return decodeTime(src, math.MaxUint64>>1, math.MaxUint32)
+}
+
+func appendNanos(dst []byte, nanos int, mask uint32) []byte {
+	v := uint32(nanos) ^ mask
+	return append(dst, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func decodeTime(src []byte, secMask uint64, nanoMask uint32) (time.Time, []byte, error) {
+	if len(src) < 12 {
+		return time.Time{}, src, ErrTruncated
+	}
+	sec := int64(binary.BigEndian.Uint64(src) ^ secMask)
+	nanos := int64(binary.BigEndian.Uint32(src[8:]) ^ nanoMask)
+	return time.Unix(sec, nanos).UTC(), src[12:], nil
+}
@@ -0,0 +1,177 @@
+package keycodec_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pascaldekloe/pile/keycodec"
+)
+
+// Sign returns -1, 0 or 1 for less, equal or more respectively.
+func sign(less, more bool) int {
+	switch {
+	case less:
+		return -1
+	case more:
+		return 1
+	}
+	return 0
+}
+
+func verifyOrder(t *testing.T, name string, want int, a, b []byte) {
+	t.Helper()
+	if got := bytes.Compare(a, b); got != want {
+		t.Errorf("%s got byte order %d, want %d, for encodings %#x and %#x", name, got, want, a, b)
+	}
+}
+
+func FuzzInt64(f *testing.F) {
+	f.Add(int64(0), int64(-1))
+	f.Add(int64(math.MinInt64), int64(math.MaxInt64))
+	f.Add(int64(255), int64(256))
+	f.Fuzz(func(t *testing.T, a, b int64) {
+		want := sign(a < b, a > b)
+		verifyOrder(t, "ascending", want, keycodec.AppendInt64(nil, a), keycodec.AppendInt64(nil, b))
+		verifyOrder(t, "descending", -want, keycodec.AppendInt64Desc(nil, a), keycodec.AppendInt64Desc(nil, b))
+
+		got, rest, err := keycodec.DecodeInt64(keycodec.AppendInt64(nil, a))
+		if err != nil || got != a || len(rest) != 0 {
+			t.Errorf("decode %d got (%d, %#x, %v)", a, got, rest, err)
+		}
+		got, rest, err = keycodec.DecodeInt64Desc(keycodec.AppendInt64Desc(nil, a))
+		if err != nil || got != a || len(rest) != 0 {
+			t.Errorf("decode descending %d got (%d, %#x, %v)", a, got, rest, err)
+		}
+	})
+}
+
+func FuzzUint64(f *testing.F) {
+	f.Add(uint64(0), uint64(math.MaxUint64))
+	f.Add(uint64(255), uint64(256))
+	f.Fuzz(func(t *testing.T, a, b uint64) {
+		want := sign(a < b, a > b)
+		verifyOrder(t, "ascending", want, keycodec.AppendUint64(nil, a), keycodec.AppendUint64(nil, b))
+		verifyOrder(t, "descending", -want, keycodec.AppendUint64Desc(nil, a), keycodec.AppendUint64Desc(nil, b))
+
+		got, rest, err := keycodec.DecodeUint64Desc(keycodec.AppendUint64Desc(nil, a))
+		if err != nil || got != a || len(rest) != 0 {
+			t.Errorf("decode descending %d got (%d, %#x, %v)", a, got, rest, err)
+		}
+	})
+}
+
+func FuzzFloat64(f *testing.F) {
+	f.Add(0.0, math.Copysign(0, -1))
+	f.Add(math.Inf(-1), -math.MaxFloat64)
+	f.Add(math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64)
+	f.Add(1.5, 1.25)
+	f.Fuzz(func(t *testing.T, a, b float64) {
+		if math.IsNaN(a) || math.IsNaN(b) {
+			t.Skip("NaN has no order")
+		}
+		want := sign(a < b, a > b)
+		if want == 0 {
+			// negative zero goes first
+			want = sign(math.Signbit(a) && !math.Signbit(b), !math.Signbit(a) && math.Signbit(b))
+		}
+		verifyOrder(t, "ascending", want, keycodec.AppendFloat64(nil, a), keycodec.AppendFloat64(nil, b))
+		verifyOrder(t, "descending", -want, keycodec.AppendFloat64Desc(nil, a), keycodec.AppendFloat64Desc(nil, b))
+
+		got, _, err := keycodec.DecodeFloat64(keycodec.AppendFloat64(nil, a))
+		if err != nil || math.Float64bits(got) != math.Float64bits(a) {
+			t.Errorf("decode %g got (%g, %v)", a, got, err)
+		}
+		got, _, err = keycodec.DecodeFloat64Desc(keycodec.AppendFloat64Desc(nil, a))
+		if err != nil || math.Float64bits(got) != math.Float64bits(a) {
+			t.Errorf("decode descending %g got (%g, %v)", a, got, err)
+		}
+	})
+}
+
+func FuzzString(f *testing.F) {
+	f.Add("", "\x00")
+	f.Add("a", "a\x00")
+	f.Add("a\x00b", "a\x01")
+	f.Add("\xff", "\xff\xff")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		want := strings.Compare(a, b)
+		verifyOrder(t, "ascending", want, keycodec.AppendString(nil, a), keycodec.AppendString(nil, b))
+		verifyOrder(t, "descending", -want, keycodec.AppendStringDesc(nil, a), keycodec.AppendStringDesc(nil, b))
+
+		got, rest, err := keycodec.DecodeString(keycodec.AppendString(nil, a))
+		if err != nil || got != a || len(rest) != 0 {
+			t.Errorf("decode %q got (%q, %#x, %v)", a, got, rest, err)
+		}
+		got, rest, err = keycodec.DecodeStringDesc(keycodec.AppendStringDesc(nil, a))
+		if err != nil || got != a || len(rest) != 0 {
+			t.Errorf("decode descending %q got (%q, %#x, %v)", a, got, rest, err)
+		}
+	})
+}
+
+// FuzzTuple verifies the order of concatenated encodings.
+func FuzzTuple(f *testing.F) {
+	f.Add("a", int64(2), "a\x00", int64(1))
+	f.Add("", int64(-1), "", int64(1))
+	f.Fuzz(func(t *testing.T, a1 string, a2 int64, b1 string, b2 int64) {
+		want := strings.Compare(a1, b1)
+		if want == 0 {
+			want = sign(a2 > b2, a2 < b2) // descending
+		}
+		a := keycodec.AppendInt64Desc(keycodec.AppendString(nil, a1), a2)
+		b := keycodec.AppendInt64Desc(keycodec.AppendString(nil, b1), b2)
+		verifyOrder(t, "tuple", want, a, b)
+
+		s, rest, err := keycodec.DecodeString(a)
+		if err != nil || s != a1 {
+			t.Fatalf("decode first got (%q, %v), want %q", s, err, a1)
+		}
+		i, rest, err := keycodec.DecodeInt64Desc(rest)
+		if err != nil || i != a2 || len(rest) != 0 {
+			t.Errorf("decode second got (%d, %#x, %v), want %d", i, rest, err, a2)
+		}
+	})
+}
+
+func FuzzTime(f *testing.F) {
+	f.Add(int64(0), int64(0), int64(-1), int64(999999999))
+	f.Add(int64(1700000000), int64(1), int64(1700000000), int64(2))
+	f.Fuzz(func(t *testing.T, sec1, nsec1, sec2, nsec2 int64) {
+		a := time.Unix(sec1, nsec1)
+		b := time.Unix(sec2, nsec2)
+		want := sign(a.Before(b), a.After(b))
+		verifyOrder(t, "ascending", want, keycodec.AppendTime(nil, a), keycodec.AppendTime(nil, b))
+		verifyOrder(t, "descending", -want, keycodec.AppendTimeDesc(nil, a), keycodec.AppendTimeDesc(nil, b))
+
+		got, rest, err := keycodec.DecodeTime(keycodec.AppendTime(nil, a))
+		if err != nil || !got.Equal(a) || len(rest) != 0 {
+			t.Errorf("decode %s got (%s, %#x, %v)", a, got, rest, err)
+		}
+		got, rest, err = keycodec.DecodeTimeDesc(keycodec.AppendTimeDesc(nil, a))
+		if err != nil || !got.Equal(a) || len(rest) != 0 {
+			t.Errorf("decode descending %s got (%s, %#x, %v)", a, got, rest, err)
+		}
+	})
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, _, err := keycodec.DecodeInt64([]byte{1, 2, 3}); !errors.Is(err, keycodec.ErrTruncated) {
+		t.Errorf("short integer got error %v, want %v", err, keycodec.ErrTruncated)
+	}
+	if _, _, err := keycodec.DecodeString([]byte("abc")); !errors.Is(err, keycodec.ErrTruncated) {
+		t.Errorf("string without terminator got error %v, want %v", err, keycodec.ErrTruncated)
+	}
+	if _, _, err := keycodec.DecodeString([]byte("abc\x00")); !errors.Is(err, keycodec.ErrTruncated) {
+		t.Errorf("string with half terminator got error %v, want %v", err, keycodec.ErrTruncated)
+	}
+	if _, _, err := keycodec.DecodeString([]byte("a\x00\x02")); !errors.Is(err, keycodec.ErrEscape) {
+		t.Errorf("string with bad escape got error %v, want %v", err, keycodec.ErrEscape)
+	}
+	if _, _, err := keycodec.DecodeTime(make([]byte, 11)); !errors.Is(err, keycodec.ErrTruncated) {
+		t.Errorf("short time got error %v, want %v", err, keycodec.ErrTruncated)
+	}
+}
@@ -214,6 +214,109 @@ func TestMapRandom(t *testing.T) {
 	}
 }
 
+func TestAppender(t *testing.T) {
+	r := rand.New(rand.NewSource(99))
+	feeds := map[string][]int{
+		"Append":  make([]int, 1000),
+		"Prepend": make([]int, 1000),
+		"Random":  r.Perm(1000),
+		"Bursts":  make([]int, 1000),
+	}
+	for i := range feeds["Append"] {
+		feeds["Append"][i] = i
+		feeds["Prepend"][i] = 999 - i
+		// 10 sequential runs of 100 keys, with runs in mixed order
+		feeds["Bursts"][i] = i/100*7%10*100 + i%100
+	}
+
+	for name, feed := range feeds {
+		t.Run(name, func(t *testing.T) {
+			reference := make(map[int]int, len(feed))
+			var m Map[int, int]
+			a := m.Appender()
+			for i, k := range feed {
+				if !a.Append(k, k+100) {
+					t.Fatalf("append %d got false", k)
+				}
+				if i%100 == 0 {
+					// modification in between
+					m.Put(k, k+100)
+				}
+				reference[k] = k + 100
+			}
+			verifyMapEqual(t, "Append", &m, reference)
+
+			for _, k := range feed[:10] {
+				if a.Append(k, -1) {
+					t.Errorf("append of present key %d got true", k)
+				}
+			}
+			verifyMapEqual(t, "Append", &m, reference)
+
+			var last int
+			n := 0
+			for c, ok := m.Least(); ok; ok = c.Ascend() {
+				if n != 0 && c.Key() <= last {
+					t.Fatalf("got key %d after %d", c.Key(), last)
+				}
+				last = c.Key()
+				n++
+			}
+			if n != len(feed) {
+				t.Errorf("iteration got %d keys, want %d", n, len(feed))
+			}
+			if t.Failed() {
+				t.Log("got:\n", dumpMap(&m))
+			}
+		})
+	}
+}
+
+func TestMapDelete(t *testing.T) {
+	r := rand.New(rand.NewSource(1337))
+	reference := make(map[uint16]int)
+	var m Map[uint16, int]
+	for i := 0; i < 20000 && !t.Failed(); i++ {
+		k := uint16(r.Intn(2000))
+		if r.Intn(5) < 3 {
+			m.Put(k, i)
+			reference[k] = i
+			continue
+		}
+
+		v, ok := m.Delete(k)
+		want, present := reference[k]
+		if ok != present || v != want {
+			t.Fatalf("delete key %d got (%d, %t), want (%d, %t)", k, v, ok, want, present)
+		}
+		delete(reference, k)
+		if err := m.Validate(); err != nil {
+			t.Fatalf("invalid after delete of key %d: %s", k, err)
+		}
+	}
+	verifyMapEqual(t, "Put and Delete", &m, reference)
+
+	// empty in order
+	for c, ok := m.Least(); ok; c, ok = m.Least() {
+		k := c.Key()
+		if _, ok := m.Delete(k); !ok {
+			t.Fatalf("delete least key %d got false", k)
+		}
+		delete(reference, k)
+		verifyMapEqual(t, "Delete least", &m, reference)
+	}
+	if m.top != nil {
+		t.Error("top node remains after deleting all keys")
+	}
+	if s := m.Stats(); s.NodeN+s.SpareNodeN != s.SpareNodeN || s.SpareNodeN == 0 {
+		t.Errorf("got %d nodes in use with %d spare after deleting all keys", s.NodeN, s.SpareNodeN)
+	}
+
+	if _, ok := m.Delete(1); ok {
+		t.Error("delete on empty Map got true")
+	}
+}
+
 func verifyMapEqual[Key Sortable, Value comparable](t *testing.T, name string, got *Map[Key, Value], want map[Key]Value) {
 	for k, v := range want {
 		switch actual, found := got.Find(k); {
@@ -226,4 +329,7 @@ func verifyMapEqual[Key Sortable, Value comparable](t *testing.T, name string, g
 	if n := got.Size(); n != len(want) {
 		t.Errorf("%s result map got Size %d, want %d", name, n, len(want))
 	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("%s result map invalid: %s", name, err)
+	}
 }
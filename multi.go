@@ -0,0 +1,158 @@
+package pile
+
+// MultiMap provides sorted Key–Value registration with duplicate Keys. Values
+// on the same Key retain the order in which they were added. The zero MultiMap
+// is empty and ready for use. Do not copy the MultiMap struct.
+type MultiMap[Key Sortable, Value any] struct {
+	check noCopy
+
+	m    Map[Key, []Value]
+	size int    // number of Values
+	modN uint64 // modification count
+}
+
+// Size returns the number of Values in the MultiMap.
+func (m *MultiMap[Key, Value]) Size() int { return m.size }
+
+// Count returns the number of Values on the Key.
+func (m *MultiMap[Key, Value]) Count(k Key) int {
+	vp := m.m.FindPointer(k)
+	if vp == nil {
+		return 0
+	}
+	return len(*vp)
+}
+
+// Add appends the Value to the Key, after any Values present on the Key.
+func (m *MultiMap[Key, Value]) Add(k Key, v Value) {
+	m.modN++
+	m.size++
+	vp := m.m.FindPointer(k)
+	if vp == nil {
+		m.m.Insert(k, []Value{v})
+	} else {
+		*vp = append(*vp, v)
+	}
+}
+
+// DeleteOne removes the Value added first on the Key, and it returns the Value
+// removed, with false for none.
+func (m *MultiMap[Key, Value]) DeleteOne(k Key) (Value, bool) {
+	vp := m.m.FindPointer(k)
+	if vp == nil {
+		var zero Value
+		return zero, false
+	}
+	m.modN++
+	m.size--
+
+	values := *vp
+	v := values[0]
+	if len(values) == 1 {
+		m.m.Delete(k)
+		return v, true
+	}
+	// Slicing off the head takes constant time. Add reallocates
+	// once the tail runs out of capacity, which frees the head.
+	var zero Value
+	values[0] = zero // release any references
+	*vp = values[1:]
+	return v, true
+}
+
+// DeleteAll removes all Values on the Key, and it returns the number of Values
+// removed.
+func (m *MultiMap[Key, Value]) DeleteAll(k Key) int {
+	values, ok := m.m.Delete(k)
+	if !ok {
+		return 0
+	}
+	m.modN++
+	m.size -= len(values)
+	return len(values)
+}
+
+// FindAll returns a new MultiCursor located at the Value added first on the
+// Key. The return is false when the Key is absent.
+func (m *MultiMap[Key, Value]) FindAll(k Key) (MultiCursor[Key, Value], bool) {
+	vp := m.m.FindPointer(k)
+	if vp == nil {
+		return MultiCursor[Key, Value]{}, false
+	}
+	return MultiCursor[Key, Value]{m: m, modN: m.modN, k: k, values: *vp}, true
+}
+
+// Ascend calls f for each Key–Value pair in the MultiMap, ascending in Key
+// order, until f returns false. Values on the same Key go in the order in which
+// they were added.
+func (m *MultiMap[Key, Value]) Ascend(f func(Key, Value) bool) {
+	for c, ok := m.m.Least(); ok; ok = c.Ascend() {
+		k := c.Key()
+		for _, v := range c.Value() {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// MultiCursor navigates over the Values of a single Key, in the order in which
+// they were added. Any Add or Delete on the MultiMap renders the MultiCursor
+// invalid. Any use of an invalid MultiCursor panics.
+type MultiCursor[Key Sortable, Value any] struct {
+	m    *MultiMap[Key, Value]
+	modN uint64 // modification count of m at creation
+
+	k      Key
+	values []Value
+	i      int
+}
+
+// Check panics when the MultiMap had modifications since the MultiCursor
+// creation.
+func (c *MultiCursor[Key, Value]) check() {
+	if c.modN != c.m.modN {
+		panic("pile: MultiCursor used after Add or Delete on MultiMap")
+	}
+}
+
+// Key returns the Key of the MultiCursor.
+func (c *MultiCursor[Key, Value]) Key() Key { return c.k }
+
+// Value returns the Value at the current position.
+func (c *MultiCursor[Key, Value]) Value() Value {
+	if c.values == nil {
+		var zero Value
+		return zero
+	}
+	c.check()
+	return c.values[c.i]
+}
+
+// Ascend moves the MultiCursor one Value closer to the Value added last on the
+// Key, up to the last one itself.
+func (c *MultiCursor[Key, Value]) Ascend() bool {
+	if c.values == nil {
+		return false
+	}
+	c.check()
+	if c.i+1 >= len(c.values) {
+		return false
+	}
+	c.i++
+	return true
+}
+
+// Descend moves the MultiCursor one Value closer to the Value added first on
+// the Key, up to the first one itself.
+func (c *MultiCursor[Key, Value]) Descend() bool {
+	if c.values == nil {
+		return false
+	}
+	c.check()
+	if c.i == 0 {
+		return false
+	}
+	c.i--
+	return true
+}
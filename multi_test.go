@@ -0,0 +1,114 @@
+package pile_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleMultiMap() {
+	var events pile.MultiMap[int, string]
+	events.Add(1200, "lunch")
+	events.Add(900, "coffee")
+	events.Add(1200, "call")
+	events.Add(1200, "walk")
+
+	for c, ok := events.FindAll(1200); ok; ok = c.Ascend() {
+		fmt.Println(c.Key(), c.Value())
+	}
+	events.DeleteOne(1200)
+	fmt.Println("count:", events.Count(1200), "size:", events.Size())
+	// Output:
+	// 1200 lunch
+	// 1200 call
+	// 1200 walk
+	// count: 2 size: 3
+}
+
+func TestMultiMap(t *testing.T) {
+	var m pile.MultiMap[int, int]
+	for i := 0; i < 1000; i++ {
+		m.Add(i%10, i)
+	}
+	if got := m.Size(); got != 1000 {
+		t.Fatalf("got size %d after 1000 adds", got)
+	}
+	if got := m.Count(3); got != 100 {
+		t.Errorf("got count %d for key 3, want 100", got)
+	}
+	if got := m.Count(10); got != 0 {
+		t.Errorf("got count %d for absent key 10", got)
+	}
+
+	// insertion order
+	c, ok := m.FindAll(7)
+	if !ok {
+		t.Fatal("find all key 7 got false")
+	}
+	for i := 7; ; i += 10 {
+		if got := c.Value(); got != i {
+			t.Fatalf("got value %d, want %d", got, i)
+		}
+		if !c.Ascend() {
+			if i != 997 {
+				t.Fatalf("ascend stopped at value %d", i)
+			}
+			break
+		}
+	}
+	for i := 997; c.Descend(); i -= 10 {
+		if got := c.Value(); got != i-10 {
+			t.Fatalf("descend got value %d, want %d", got, i-10)
+		}
+	}
+
+	// ascend over all
+	var last, n int
+	m.Ascend(func(k, v int) bool {
+		if k < last || v%10 != k {
+			t.Errorf("got key %d, value %d after key %d", k, v, last)
+		}
+		last = k
+		n++
+		return true
+	})
+	if n != 1000 {
+		t.Errorf("ascend got %d values, want 1000", n)
+	}
+
+	// delete oldest first
+	for i := 5; i < 1000; i += 10 {
+		v, ok := m.DeleteOne(5)
+		if !ok || v != i {
+			t.Fatalf("delete one got (%d, %t), want (%d, true)", v, ok, i)
+		}
+	}
+	if v, ok := m.DeleteOne(5); ok {
+		t.Errorf("delete one on empty key got value %d", v)
+	}
+	if _, ok := m.FindAll(5); ok {
+		t.Error("find all on empty key got true")
+	}
+
+	if got := m.DeleteAll(2); got != 100 {
+		t.Errorf("delete all got %d, want 100", got)
+	}
+	if got := m.DeleteAll(2); got != 0 {
+		t.Errorf("delete all again got %d, want 0", got)
+	}
+	if got := m.Size(); got != 800 {
+		t.Errorf("got size %d, want 800", got)
+	}
+
+	verifyPanic(t, "value after add", func() {
+		c, _ := m.FindAll(1)
+		m.Add(1, -1)
+		c.Value()
+	})
+	verifyPanic(t, "ascend after delete", func() {
+		c, _ := m.FindAll(1)
+		m.DeleteOne(1)
+		c.Ascend()
+	})
+}
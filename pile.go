@@ -17,40 +17,49 @@ type pair[Key Sortable, Value any] struct {
 // A node holds up to tree pairs in ascending Key order.
 // Nodes on ground level do not have any subnodes.
 // Higher nodes stack on pairN plus one subnodes.
+// The fields in use by search go first, with Keys apart from Values, such that
+// a search touches as few cache lines as possible, regardless of the Value size.
 type node[Key Sortable, Value any] struct {
-	above *node[Key, Value]
-	pairN int                  // actual pairs count
-	subs  [4]*node[Key, Value] // directly under
-	pairs [3]pair[Key, Value]  // own entries
+	pairN  int                  // actual pairs count
+	keys   [3]Key               // own entries
+	subs   [4]*node[Key, Value] // directly under
+	above  *node[Key, Value]
+	values [3]Value // own entries
 }
 
-func (m *Map[Key, Value]) newNodeWith1(above *node[Key, Value], p pair[Key, Value]) *node[Key, Value] {
+func (m *Map[Key, Value]) newNodeWith1(above *node[Key, Value], k Key, v Value) *node[Key, Value] {
 	t := m.newNode()
 	t.above = above
-	t.pairs[0] = p
+	t.keys[0] = k
+	t.values[0] = v
 	t.pairN = 1
 	return t
 }
 
-func (m *Map[Key, Value]) newNodeWith2(above *node[Key, Value], p1, p2 pair[Key, Value]) *node[Key, Value] {
+func (m *Map[Key, Value]) newNodeWith2(above *node[Key, Value], k1 Key, v1 Value, k2 Key, v2 Value) *node[Key, Value] {
 	t := m.newNode()
 	t.above = above
-	t.pairs[0] = p1
-	t.pairs[1] = p2
+	t.keys[0] = k1
+	t.values[0] = v1
+	t.keys[1] = k2
+	t.values[1] = v2
 	t.pairN = 2
 	return t
 }
 
-// NodeBatchN sets the number of nodes allocated together.
-const nodeBatchN = 512 // must be a power of two
-
 func (m *Map[Key, Value]) newNode() *node[Key, Value] {
-	if m.nodeN == 0 || m.nodeQ == nil {
-		m.nodeQ = new([nodeBatchN]node[Key, Value])
-		m.nodeN = nodeBatchN
+	if m.arena != nil {
+		return m.arena.newNode()
+	}
+	return m.nodes.newNode()
+}
+
+func (m *Map[Key, Value]) recycleNode(t *node[Key, Value]) {
+	if m.arena != nil {
+		m.arena.recycleNode(t)
+	} else {
+		m.nodes.recycleNode(t)
 	}
-	m.nodeN--
-	return &m.nodeQ[m.nodeN&(nodeBatchN-1)]
 }
 
 // Map provides sorted Key–Value registration. The zero Map is empty and ready
@@ -65,12 +74,30 @@ type Map[Key Sortable, Value any] struct {
 
 	top *node[Key, Value]
 
+	// structural modification count
+	modN uint64
+
 	// reusable buffer for level push
 	split pair[Key, Value]
 
 	// allocation pool
-	nodeN int
-	nodeQ *[nodeBatchN]node[Key, Value]
+	arena *Arena[Key, Value] // shared, if any
+	nodes Arena[Key, Value]  // own
+
+	// Fix, if any, gets called on each node with a change in either its
+	// pairs or in its subnodes, from the bottom up. All operations call
+	// fix, with the exception of writes through FindPointer.
+	fix func(*node[Key, Value])
+}
+
+// FixUp calls fix, if any, on t and on each node above t.
+func (m *Map[Key, Value]) fixUp(t *node[Key, Value]) {
+	if m.fix == nil {
+		return
+	}
+	for ; t != nil; t = t.above {
+		m.fix(t)
+	}
 }
 
 // Size returns the number of Keys in the Map.
@@ -109,13 +136,13 @@ func (m *Map[Key, Value]) AppendKeys(dst []Key) []Key {
 func (t *node[Key, Value]) appendKeys(dst []Key) []Key {
 	if t != nil {
 		dst = t.subs[0].appendKeys(dst)
-		dst = append(dst, t.pairs[0].K)
+		dst = append(dst, t.keys[0])
 		dst = t.subs[1].appendKeys(dst)
 		if t.pairN > 1 {
-			dst = append(dst, t.pairs[1].K)
+			dst = append(dst, t.keys[1])
 			dst = t.subs[2].appendKeys(dst)
 			if t.pairN > 2 {
-				dst = append(dst, t.pairs[2].K)
+				dst = append(dst, t.keys[2])
 				dst = t.subs[3].appendKeys(dst)
 			}
 		}
@@ -135,13 +162,13 @@ func (m *Map[Key, Value]) AppendValues(dst []Value) []Value {
 func (t *node[Key, Value]) appendValues(dst []Value) []Value {
 	if t != nil {
 		dst = t.subs[0].appendValues(dst)
-		dst = append(dst, t.pairs[0].V)
+		dst = append(dst, t.values[0])
 		dst = t.subs[1].appendValues(dst)
 		if t.pairN > 1 {
-			dst = append(dst, t.pairs[1].V)
+			dst = append(dst, t.values[1])
 			dst = t.subs[2].appendValues(dst)
 			if t.pairN > 2 {
-				dst = append(dst, t.pairs[2].V)
+				dst = append(dst, t.values[2])
 				dst = t.subs[3].appendValues(dst)
 			}
 		}
@@ -167,16 +194,16 @@ func (m *Map[Key, Value]) AppendPairs(keys []Key, values []Value) ([]Key, []Valu
 func (t *node[Key, Value]) appendPairs(keys []Key, values []Value) ([]Key, []Value) {
 	if t != nil {
 		keys, values = t.subs[0].appendPairs(keys, values)
-		keys = append(keys, t.pairs[0].K)
-		values = append(values, t.pairs[0].V)
+		keys = append(keys, t.keys[0])
+		values = append(values, t.values[0])
 		keys, values = t.subs[1].appendPairs(keys, values)
 		if t.pairN > 1 {
-			keys = append(keys, t.pairs[1].K)
-			values = append(values, t.pairs[1].V)
+			keys = append(keys, t.keys[1])
+			values = append(values, t.values[1])
 			keys, values = t.subs[2].appendPairs(keys, values)
 			if t.pairN > 2 {
-				keys = append(keys, t.pairs[2].K)
-				values = append(values, t.pairs[2].V)
+				keys = append(keys, t.keys[2])
+				values = append(values, t.values[2])
 				keys, values = t.subs[3].appendPairs(keys, values)
 			}
 		}
@@ -216,6 +243,22 @@ func (keys *Set[Key]) Insert(entry Key) bool {
 	return keys.m.Insert(entry, struct{}{})
 }
 
+// Delete removes the Key from the Set if and only if the Key is present.
+func (keys *Set[Key]) Delete(k Key) bool {
+	_, ok := keys.m.Delete(k)
+	return ok
+}
+
+// Clear removes all Keys from the Set. The nodes are kept for reuse.
+func (keys *Set[Key]) Clear() {
+	keys.m.Clear()
+}
+
+// Release removes all Keys from the Set, and it frees all memory allocated.
+func (keys *Set[Key]) Release() {
+	keys.m.Release()
+}
+
 // At returns a new Cursor at located the Key, with false for none. A Delete or
 // Insert renders the Cursor invalid.
 func (keys *Set[Key]) At(k Key) (Cursor[Key, struct{}], bool) {
@@ -0,0 +1,81 @@
+package pile
+
+import "strings"
+
+// The prefix operations are functions, rather than methods on Map, because
+// methods can not constrain the Key type any further than Map does.
+
+// PrefixScan returns a new PrefixCursor located at the least Key which starts
+// with prefix. The return is false when no Key in the Map starts with prefix.
+// A Delete or Insert renders the PrefixCursor invalid.
+func PrefixScan[Key ~string, Value any](m *Map[Key, Value], prefix Key) (PrefixCursor[Key, Value], bool) {
+	c, ok := m.Ceil(prefix)
+	if !ok || !strings.HasPrefix(string(c.Key()), string(prefix)) {
+		return PrefixCursor[Key, Value]{}, false
+	}
+	return PrefixCursor[Key, Value]{c: c, prefix: prefix}, true
+}
+
+// PrefixCursor navigates over the Keys with a common prefix. Keys without the
+// prefix are out of bounds. Any use of an invalid PrefixCursor panics.
+type PrefixCursor[Key ~string, Value any] struct {
+	c      Cursor[Key, Value]
+	prefix Key
+}
+
+// Key returns the Key at the current position.
+func (c *PrefixCursor[Key, Value]) Key() Key { return c.c.Key() }
+
+// Value returns the Value at the current position.
+func (c *PrefixCursor[Key, Value]) Value() Value { return c.c.Value() }
+
+// Swap sets the Value and it returns the previous one.
+func (c *PrefixCursor[Key, Value]) Swap(v Value) (previous Value) { return c.c.Swap(v) }
+
+// Ascend moves the PrefixCursor one Key closer to the greatest Key with the
+// prefix, up to that Key itself.
+func (c *PrefixCursor[Key, Value]) Ascend() bool {
+	moved := c.c
+	if !moved.Ascend() || !strings.HasPrefix(string(moved.Key()), string(c.prefix)) {
+		return false
+	}
+	c.c = moved
+	return true
+}
+
+// Descend moves the PrefixCursor one Key closer to the least Key with the
+// prefix, up to that Key itself.
+func (c *PrefixCursor[Key, Value]) Descend() bool {
+	moved := c.c
+	if !moved.Descend() || !strings.HasPrefix(string(moved.Key()), string(c.prefix)) {
+		return false
+	}
+	c.c = moved
+	return true
+}
+
+// LongestPrefix returns the longest Key in the Map which is a prefix of s, with
+// false for none. Each Key tried is the greatest one up to a shorter prefix of
+// s, which takes one search per distinct common-prefix length at most.
+func LongestPrefix[Key ~string, Value any](m *Map[Key, Value], s Key) (Key, Value, bool) {
+	for {
+		c, ok := m.Floor(s)
+		if !ok {
+			var zeroKey Key
+			var zeroValue Value
+			return zeroKey, zeroValue, false
+		}
+		k := c.Key()
+		if strings.HasPrefix(string(s), string(k)) {
+			return k, c.Value(), true
+		}
+
+		// Any prefix of s in the Map is not greater than k,
+		// and thus it is also a prefix of what k and s share.
+		n := 0
+		for n < len(k) && n < len(s) && k[n] == s[n] {
+			n++
+		}
+		s = s[:n]
+	}
+}
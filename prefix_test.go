@@ -0,0 +1,99 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExamplePrefixScan() {
+	var m pile.Map[string, int]
+	m.Put("user:41:name", 1)
+	m.Put("user:42:mail", 2)
+	m.Put("user:42:name", 3)
+	m.Put("user:420:name", 4)
+
+	for c, ok := pile.PrefixScan(&m, "user:42:"); ok; ok = c.Ascend() {
+		fmt.Println(c.Key(), c.Value())
+	}
+	// Output:
+	// user:42:mail 2
+	// user:42:name 3
+}
+
+func ExampleLongestPrefix() {
+	var routes pile.Map[string, string]
+	routes.Put("/", "root")
+	routes.Put("/api/", "api")
+	routes.Put("/api/v2/", "api v2")
+
+	k, v, _ := pile.LongestPrefix(&routes, "/api/v1/users")
+	fmt.Println(k, v)
+	// Output: /api/ api
+}
+
+func TestPrefix(t *testing.T) {
+	var m pile.Map[string, int]
+	var keys []string
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 2000; i++ {
+		b := make([]byte, r.Intn(6))
+		for j := range b {
+			b[j] = "abc"[r.Intn(3)]
+		}
+		if m.Insert(string(b), i) {
+			keys = append(keys, string(b))
+		}
+	}
+	sort.Strings(keys)
+
+	for i := 0; i < 500; i++ {
+		b := make([]byte, r.Intn(8))
+		for j := range b {
+			b[j] = "abcd"[r.Intn(4)]
+		}
+		s := string(b)
+
+		var want []string
+		var longest string
+		var found bool
+		for _, k := range keys {
+			if strings.HasPrefix(k, s) {
+				want = append(want, k)
+			}
+			if strings.HasPrefix(s, k) && len(k) >= len(longest) {
+				longest, found = k, true
+			}
+		}
+
+		var got []string
+		c, ok := pile.PrefixScan(&m, s)
+		for ; ok; ok = c.Ascend() {
+			got = append(got, c.Key())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("prefix scan %q got %q, want %q", s, got, want)
+		}
+		if len(got) != 0 {
+			// back to the start
+			n := 1
+			for c.Descend() {
+				n++
+			}
+			if n != len(got) || c.Key() != got[0] {
+				t.Errorf("prefix scan %q descended %d keys to %q, want %d keys to %q", s, n, c.Key(), len(got), got[0])
+			}
+		}
+
+		k, v, ok := pile.LongestPrefix(&m, s)
+		if ok != found || k != longest {
+			t.Errorf("longest prefix of %q got (%q, %t), want (%q, %t)", s, k, ok, longest, found)
+		} else if want, _ := m.Find(k); ok && v != want {
+			t.Errorf("longest prefix of %q got value %d, want %d", s, v, want)
+		}
+	}
+}
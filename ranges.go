@@ -0,0 +1,179 @@
+package pile
+
+// RangeMap provides Value registration on disjoint Key intervals. Each interval
+// includes its low bound, and it excludes its high bound, as in [lo, hi).
+// Adjacent intervals with an equal Value merge into one, such that iteration
+// yields maximal runs only. The zero RangeMap is empty and ready for use. Do
+// not copy the RangeMap struct.
+type RangeMap[Key Sortable, Value comparable] struct {
+	check noCopy
+
+	spans Map[Key, span[Key, Value]] // indexed by low bound
+}
+
+// Span is a RangeMap entry.
+type span[Key Sortable, Value comparable] struct {
+	hi Key // exclusive bound
+	v  Value
+}
+
+// Size returns the number of intervals in the RangeMap.
+func (m *RangeMap[Key, Value]) Size() int { return m.spans.Size() }
+
+// Lookup returns the Value of the interval which contains the Key, with false
+// for none.
+func (m *RangeMap[Key, Value]) Lookup(k Key) (Value, bool) {
+	c, ok := m.spans.Floor(k)
+	if !ok {
+		var zero Value
+		return zero, false
+	}
+	s := c.Value()
+	if k >= s.hi {
+		var zero Value
+		return zero, false
+	}
+	return s.v, true
+}
+
+// Set assigns the Value to the interval [lo, hi). Any Values present in the
+// interval are replaced, which may split intervals on the bounds. Set is a
+// no-op when lo is not less than hi.
+func (m *RangeMap[Key, Value]) Set(lo, hi Key, v Value) {
+	if !(lo < hi) {
+		return
+	}
+
+	// interval on the left
+	if c, ok := m.before(lo); ok {
+		s := c.Value()
+		switch {
+		case s.v == v && s.hi >= lo:
+			// merge
+			lo = c.Key()
+			if s.hi > hi {
+				hi = s.hi
+			}
+			m.spans.Delete(lo)
+		case s.hi > lo:
+			// split
+			c.Swap(span[Key, Value]{hi: lo, v: s.v})
+			if s.hi > hi {
+				m.spans.Insert(hi, s)
+				m.spans.Insert(lo, span[Key, Value]{hi: hi, v: v})
+				return
+			}
+		}
+	}
+
+	// intervals on the inside, plus the one adjacent on the right
+	for {
+		c, ok := m.spans.Ceil(lo)
+		if !ok || c.Key() > hi {
+			break
+		}
+		k, s := c.Key(), c.Value()
+		if k == hi && s.v != v {
+			break
+		}
+		m.spans.Delete(k)
+		if s.hi > hi {
+			if s.v == v {
+				hi = s.hi
+			} else {
+				m.spans.Insert(hi, s)
+				break
+			}
+		}
+	}
+
+	m.spans.Insert(lo, span[Key, Value]{hi: hi, v: v})
+}
+
+// Delete removes any Values from the interval [lo, hi), which may split
+// intervals on the bounds.
+func (m *RangeMap[Key, Value]) Delete(lo, hi Key) {
+	if !(lo < hi) {
+		return
+	}
+
+	// interval on the left
+	if c, ok := m.before(lo); ok {
+		s := c.Value()
+		if s.hi > lo {
+			c.Swap(span[Key, Value]{hi: lo, v: s.v})
+			if s.hi > hi {
+				m.spans.Insert(hi, s)
+				return
+			}
+		}
+	}
+
+	// intervals on the inside
+	for {
+		c, ok := m.spans.Ceil(lo)
+		if !ok || c.Key() >= hi {
+			break
+		}
+		k, s := c.Key(), c.Value()
+		m.spans.Delete(k)
+		if s.hi > hi {
+			m.spans.Insert(hi, s)
+			break
+		}
+	}
+}
+
+// Before returns a Cursor at the interval with the greatest low bound less than
+// k, with false for none.
+func (m *RangeMap[Key, Value]) before(k Key) (Cursor[Key, span[Key, Value]], bool) {
+	c, ok := m.spans.Floor(k)
+	if ok && c.Key() == k {
+		ok = c.Descend()
+	}
+	return c, ok
+}
+
+// Least returns a new RangeCursor located at the interval with the lowest
+// bounds in the RangeMap. The return is false when RangeMap is empty. Any Set
+// or Delete renders the RangeCursor invalid.
+func (m *RangeMap[Key, Value]) Least() (RangeCursor[Key, Value], bool) {
+	c, ok := m.spans.Least()
+	return RangeCursor[Key, Value]{c}, ok
+}
+
+// Most returns a new RangeCursor located at the interval with the highest
+// bounds in the RangeMap. The return is false when RangeMap is empty. Any Set
+// or Delete renders the RangeCursor invalid.
+func (m *RangeMap[Key, Value]) Most() (RangeCursor[Key, Value], bool) {
+	c, ok := m.spans.Most()
+	return RangeCursor[Key, Value]{c}, ok
+}
+
+// RangeCursor navigates over the intervals of a RangeMap. Any use of an invalid
+// RangeCursor panics.
+type RangeCursor[Key Sortable, Value comparable] struct {
+	c Cursor[Key, span[Key, Value]]
+}
+
+// Lo returns the inclusive bound of the interval at the current position.
+func (c *RangeCursor[Key, Value]) Lo() Key { return c.c.Key() }
+
+// Hi returns the exclusive bound of the interval at the current position.
+func (c *RangeCursor[Key, Value]) Hi() Key {
+	s := c.c.Value()
+	return s.hi
+}
+
+// Value returns the Value of the interval at the current position.
+func (c *RangeCursor[Key, Value]) Value() Value {
+	s := c.c.Value()
+	return s.v
+}
+
+// Ascend moves the RangeCursor one interval closer to Most, up to Most itself.
+func (c *RangeCursor[Key, Value]) Ascend() bool { return c.c.Ascend() }
+
+// Descend moves the RangeCursor one interval closer to Least, up to Least
+// itself.
+func (c *RangeCursor[Key, Value]) Descend() bool { return c.c.Descend() }
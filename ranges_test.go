@@ -0,0 +1,89 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleRangeMap() {
+	var owners pile.RangeMap[uint32, string]
+	owners.Set(0x0a000000, 0x0b000000, "intranet")
+	owners.Set(0x0a000100, 0x0a000200, "lab")
+	owners.Set(0x0a000200, 0x0a000300, "lab")
+
+	for c, ok := owners.Least(); ok; ok = c.Ascend() {
+		fmt.Printf("%08x–%08x %s\n", c.Lo(), c.Hi(), c.Value())
+	}
+	owner, _ := owners.Lookup(0x0a0002ff)
+	fmt.Println(owner)
+	// Output:
+	// 0a000000–0a000100 intranet
+	// 0a000100–0a000300 lab
+	// 0a000300–0b000000 intranet
+	// lab
+}
+
+func TestRangeMap(t *testing.T) {
+	const keyN = 64
+	var m pile.RangeMap[int8, byte]
+	var reference [keyN]byte // zero for none
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 5000; i++ {
+		lo := r.Intn(keyN)
+		hi := lo + r.Intn(keyN/4)
+		if hi > keyN {
+			hi = keyN
+		}
+		op := "delete"
+		if r.Intn(3) != 0 {
+			v := byte('a' + r.Intn(3))
+			op = fmt.Sprintf("set %q", v)
+			m.Set(int8(lo), int8(hi), v)
+			for k := lo; k < hi; k++ {
+				reference[k] = v
+			}
+		} else {
+			m.Delete(int8(lo), int8(hi))
+			for k := lo; k < hi; k++ {
+				reference[k] = 0
+			}
+		}
+
+		for k, want := range reference {
+			got, ok := m.Lookup(int8(k))
+			if ok != (want != 0) || got != want {
+				t.Fatalf("after %s [%d, %d): lookup %d got (%q, %t), want %q", op, lo, hi, k, got, ok, want)
+			}
+		}
+		if got, want := dumpRuns(&m), referenceRuns(reference[:]); got != want {
+			t.Fatalf("after %s [%d, %d): got runs %s, want %s", op, lo, hi, got, want)
+		}
+	}
+}
+
+func dumpRuns(m *pile.RangeMap[int8, byte]) string {
+	var s string
+	for c, ok := m.Least(); ok; ok = c.Ascend() {
+		s += fmt.Sprintf("[%d,%d)%c", c.Lo(), c.Hi(), c.Value())
+	}
+	return s
+}
+
+func referenceRuns(values []byte) string {
+	var s string
+	for lo := 0; lo < len(values); {
+		hi := lo + 1
+		for hi < len(values) && values[hi] == values[lo] {
+			hi++
+		}
+		if values[lo] != 0 {
+			s += fmt.Sprintf("[%d,%d)%c", lo, hi, values[lo])
+		}
+		lo = hi
+	}
+	return s
+}
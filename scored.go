@@ -0,0 +1,160 @@
+package pile
+
+import "math"
+
+// Score is a constraint for ScoredSet.
+type Score interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~float32 | ~float64
+}
+
+// ScoreKey returns an unsigned integer in the same order as the Score. Negative
+// zero maps to positive zero, as they compare equal. NaN has no order.
+func scoreKey[S Score](s S) uint64 {
+	half := 0.5
+	if S(half) != 0 { // floating-point
+		f := float64(s)
+		if f != f {
+			panic("pile: NaN score")
+		}
+		if f == 0 {
+			return 1 << 63
+		}
+		bits := math.Float64bits(f)
+		if bits&(1<<63) != 0 {
+			return ^bits // negative in reverse
+		}
+		return bits | 1<<63
+	}
+
+	var zero S
+	if zero-1 > zero { // unsigned
+		return uint64(s)
+	}
+	return uint64(int64(s)) ^ 1<<63 // sign flip
+}
+
+// ScoredSet provides Member registration with a Score each, like the sorted
+// sets from Redis do. Members are ordered by Score, and then by Member for any
+// equal Scores. Scores must not be NaN. The zero ScoredSet is empty and ready
+// for use. Do not copy the ScoredSet struct.
+type ScoredSet[Member Sortable, S Score] struct {
+	check noCopy
+
+	scores Map[Member, S]
+
+	// Members per Score, with a count of Members in each node
+	byScore subMaps[uint64, Member, augValue[S, int]]
+	memberN augment[Member, S, int]
+
+	// number of Members per Score, with a sum in each node
+	scoreN augMap[uint64, int, int]
+}
+
+// Size returns the number of Members in the ScoredSet.
+func (z *ScoredSet[Member, S]) Size() int { return z.scores.Size() }
+
+// Score returns the Score of the Member, with false for none.
+func (z *ScoredSet[Member, S]) Score(m Member) (S, bool) {
+	return z.scores.Find(m)
+}
+
+// Put assigns the Score to the Member regardless whether the Member is present
+// or not. The return is true when the Member was absent.
+func (z *ScoredSet[Member, S]) Put(m Member, s S) bool {
+	sp := z.scores.FindPointer(m)
+	if sp == nil {
+		z.scores.Insert(m, s)
+		z.index(m, s)
+		return true
+	}
+	if *sp != s {
+		z.unindex(m, *sp)
+		*sp = s
+		z.index(m, s)
+	}
+	return false
+}
+
+// IncrBy adds delta to the Score of the Member, and it returns the new Score.
+// Absent Members start with a Score of zero.
+func (z *ScoredSet[Member, S]) IncrBy(m Member, delta S) S {
+	s, _ := z.scores.Find(m)
+	s += delta
+	z.Put(m, s)
+	return s
+}
+
+// Delete removes the Member from the ScoredSet, and it returns the Score
+// removed, with false for none.
+func (z *ScoredSet[Member, S]) Delete(m Member) (S, bool) {
+	s, ok := z.scores.Delete(m)
+	if ok {
+		z.unindex(m, s)
+	}
+	return s, ok
+}
+
+// Index adds the Member to the secondary order on Score s.
+func (z *ScoredSet[Member, S]) index(m Member, s S) {
+	if z.byScore.fix == nil {
+		z.memberN = augment[Member, S, int]{lift: countOne[S], combine: sum}
+		z.byScore.fix = z.memberN.update
+		z.scoreN.init(identity[int], sum)
+	}
+
+	k := scoreKey(s)
+	z.byScore.subFor(k).Insert(m, augValue[S, int]{v: s})
+	if p, t := z.scoreN.findPointer(k); p != nil {
+		*p++
+		z.scoreN.refresh(t)
+	} else {
+		z.scoreN.insert(k, 1)
+	}
+}
+
+// Unindex removes the Member from the secondary order on Score s.
+func (z *ScoredSet[Member, S]) unindex(m Member, s S) {
+	k := scoreKey(s)
+	z.byScore.delete(k, m)
+	p, t := z.scoreN.findPointer(k)
+	if *p == 1 {
+		z.scoreN.delete(k)
+	} else {
+		*p--
+		z.scoreN.refresh(t)
+	}
+}
+
+func countOne[T any](T) int { return 1 }
+func identity[T any](v T) T { return v }
+func sum(a, b int) int      { return a + b }
+
+// RangeByScore calls f for each Member with a Score in [lo, hi], ascending in
+// Score order, until f returns false.
+func (z *ScoredSet[Member, S]) RangeByScore(lo, hi S, f func(Member, S) bool) {
+	hiKey := scoreKey(hi)
+	for c, ok := z.byScore.byA.Ceil(scoreKey(lo)); ok && c.Key() <= hiKey; ok = c.Ascend() {
+		members := c.Value()
+		for mc, ok := members.Least(); ok; ok = mc.Ascend() {
+			if !f(mc.Key(), mc.Value().v) {
+				return
+			}
+		}
+	}
+}
+
+// RankOf returns the number of Members ordered before the Member, with false
+// for none. The cost is logarithmic to the size of the ScoredSet, as nodes
+// count the Members below.
+func (z *ScoredSet[Member, S]) RankOf(m Member) (int, bool) {
+	s, ok := z.scores.Find(m)
+	if !ok {
+		return 0, false
+	}
+	k := scoreKey(s)
+	rank := z.scoreN.aggregateBelow(0, z.scoreN.m.top, k)
+	members, _ := z.byScore.find(k)
+	return z.memberN.aggregateBelow(rank, members.top, m), true
+}
@@ -0,0 +1,137 @@
+package pile_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleScoredSet() {
+	var board pile.ScoredSet[string, int]
+	board.Put("alice", 30)
+	board.Put("bob", 10)
+	board.Put("carol", 20)
+	board.IncrBy("bob", 15)
+
+	board.RangeByScore(20, 30, func(name string, score int) bool {
+		fmt.Println(name, score)
+		return true
+	})
+	rank, _ := board.RankOf("alice")
+	fmt.Println("alice rank:", rank)
+	// Output:
+	// carol 20
+	// bob 25
+	// alice 30
+	// alice rank: 2
+}
+
+func TestScoredSet(t *testing.T) {
+	var z pile.ScoredSet[uint16, int8]
+	reference := make(map[uint16]int8)
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 5000; i++ {
+		m := uint16(r.Intn(300))
+		switch r.Intn(4) {
+		case 0:
+			_, present := reference[m]
+			s, ok := z.Delete(m)
+			if ok != present || s != reference[m] {
+				t.Fatalf("delete member %d got (%d, %t), want (%d, %t)", m, s, ok, reference[m], present)
+			}
+			delete(reference, m)
+		case 1:
+			delta := int8(r.Intn(5) - 2)
+			want := reference[m] + delta
+			if got := z.IncrBy(m, delta); got != want {
+				t.Fatalf("increment member %d by %d got %d, want %d", m, delta, got, want)
+			}
+			reference[m] = want
+		default:
+			s := int8(r.Intn(20))
+			_, present := reference[m]
+			if got := z.Put(m, s); got == present {
+				t.Fatalf("put member %d got %t with presence %t", m, got, present)
+			}
+			reference[m] = s
+		}
+	}
+
+	if got, want := z.Size(), len(reference); got != want {
+		t.Errorf("got size %d, want %d", got, want)
+	}
+
+	// reference order on (score, member)
+	order := make([]uint16, 0, len(reference))
+	for m := range reference {
+		order = append(order, m)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		si, sj := reference[order[i]], reference[order[j]]
+		return si < sj || si == sj && order[i] < order[j]
+	})
+	for want, m := range order {
+		rank, ok := z.RankOf(m)
+		if !ok || rank != want {
+			t.Errorf("rank of member %d got (%d, %t), want (%d, true)", m, rank, ok, want)
+		}
+		if s, ok := z.Score(m); !ok || s != reference[m] {
+			t.Errorf("score of member %d got (%d, %t), want (%d, true)", m, s, ok, reference[m])
+		}
+	}
+	if _, ok := z.RankOf(999); ok {
+		t.Error("rank of absent member got true")
+	}
+
+	for _, bounds := range [][2]int8{{-128, 127}, {3, 7}, {5, 5}, {8, 2}, {100, 127}} {
+		var want []uint16
+		for _, m := range order {
+			if s := reference[m]; s >= bounds[0] && s <= bounds[1] {
+				want = append(want, m)
+			}
+		}
+		var got []uint16
+		z.RangeByScore(bounds[0], bounds[1], func(m uint16, s int8) bool {
+			if s != reference[m] {
+				t.Errorf("range got member %d with score %d, want %d", m, s, reference[m])
+			}
+			got = append(got, m)
+			return true
+		})
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("range by score [%d, %d] got %d, want %d", bounds[0], bounds[1], got, want)
+		}
+	}
+}
+
+func TestScoredSetFloat(t *testing.T) {
+	var z pile.ScoredSet[string, float64]
+	z.Put("a", 0.5)
+	z.Put("b", -1e300)
+	z.Put("c", math.Inf(1))
+	z.Put("d", math.Copysign(0, -1))
+	z.Put("e", 0)
+	z.Put("f", -0.25)
+	z.Put("g", math.Inf(-1))
+	z.IncrBy("a", 0.25)
+
+	want := []string{"g", "b", "f", "d", "e", "a", "c"}
+	for rank, m := range want {
+		if got, ok := z.RankOf(m); !ok || got != rank {
+			t.Errorf("rank of member %q got (%d, %t), want (%d, true)", m, got, ok, rank)
+		}
+	}
+
+	var got []string
+	z.RangeByScore(-1, 1, func(m string, s float64) bool {
+		got = append(got, fmt.Sprint(m, " ", s))
+		return true
+	})
+	if s, want := fmt.Sprint(got), "[f -0.25 d -0 e 0 a 0.75]"; s != want {
+		t.Errorf("range by score [-1, 1] got %s, want %s", s, want)
+	}
+}
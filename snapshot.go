@@ -0,0 +1,245 @@
+package pile
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SnapshotMap provides sorted Key–Value registration with lock-free reads.
+// Readers never block, as each read operates on an immutable Snapshot. Writes
+// copy the nodes on their path from the top, and they publish the result as a
+// new Snapshot atomically. Writes are serialized with a mutex. The zero
+// SnapshotMap is empty and ready for use. Do not copy the SnapshotMap struct.
+//
+// Path copying costs one node allocation per level on each write. Use Map or
+// SyncMap for write-heavy workloads.
+type SnapshotMap[Key Sortable, Value any] struct {
+	writeMutex sync.Mutex
+	top        atomic.Value // *node[Key, Value]
+}
+
+// Snapshot is an immutable state of a SnapshotMap. Nodes in a Snapshot do not
+// link to the node above, which is why Cursors are not available. Use the
+// callback-style range methods instead.
+type Snapshot[Key Sortable, Value any] struct {
+	top *node[Key, Value]
+}
+
+// Snapshot returns the current state without blocking.
+func (m *SnapshotMap[Key, Value]) Snapshot() Snapshot[Key, Value] {
+	t, _ := m.top.Load().(*node[Key, Value])
+	return Snapshot[Key, Value]{top: t}
+}
+
+// Size returns the number of Keys in the current Snapshot.
+func (m *SnapshotMap[Key, Value]) Size() int {
+	s := m.Snapshot()
+	return s.Size()
+}
+
+// Find returns the Value assigned to the Key in the current Snapshot.
+func (m *SnapshotMap[Key, Value]) Find(k Key) (Value, bool) {
+	s := m.Snapshot()
+	return s.Find(k)
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (m *SnapshotMap[Key, Value]) Insert(k Key, v Value) bool {
+	return m.write(k, v, true, false)
+}
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (m *SnapshotMap[Key, Value]) Update(k Key, v Value) bool {
+	return m.write(k, v, false, true)
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not.
+func (m *SnapshotMap[Key, Value]) Put(k Key, v Value) {
+	m.write(k, v, true, true)
+}
+
+func (m *SnapshotMap[Key, Value]) write(k Key, v Value, insert, update bool) bool {
+	m.writeMutex.Lock()
+	defer m.writeMutex.Unlock()
+
+	top, _ := m.top.Load().(*node[Key, Value])
+	if top == nil {
+		if !insert {
+			return false
+		}
+		top = &node[Key, Value]{pairN: 1}
+		top.keys[0] = k
+		top.values[0] = v
+		m.top.Store(top)
+		return true
+	}
+
+	c, split, splitRight, ok := top.copyOnWrite(k, v, insert, update)
+	if !ok {
+		return false
+	}
+	if splitRight != nil {
+		grow := &node[Key, Value]{pairN: 1}
+		grow.keys[0] = split.K
+		grow.values[0] = split.V
+		grow.subs[0] = c
+		grow.subs[1] = splitRight
+		c = grow
+	}
+	m.top.Store(c)
+	return true
+}
+
+// CopyOnWrite returns a copy of t with the Key–Value pair applied, or false for
+// no change. When the copy overflows, then the return is split in two with a
+// separating pair.
+func (t *node[Key, Value]) copyOnWrite(k Key, v Value, insert, update bool) (c *node[Key, Value], split pair[Key, Value], splitRight *node[Key, Value], ok bool) {
+	i := 0
+	for i < t.pairN && k > t.keys[i] {
+		i++
+	}
+	if i < t.pairN && k == t.keys[i] {
+		if !update {
+			return nil, split, nil, false
+		}
+		c = t.copy()
+		c.values[i] = v
+		return c, split, nil, true
+	}
+
+	// new pair goes in at index i
+	p := pair[Key, Value]{K: k, V: v}
+	var left, right *node[Key, Value]
+	if t.subs[0] != nil {
+		left, p, right, ok = t.subs[i].copyOnWrite(k, v, insert, update)
+		if !ok {
+			return nil, split, nil, false
+		}
+		if right == nil {
+			c = t.copy()
+			c.subs[i] = left
+			return c, split, nil, true
+		}
+	} else if !insert {
+		return nil, split, nil, false
+	}
+
+	// stage the pairs with one in excess
+	var keys [4]Key
+	var values [4]Value
+	var subs [5]*node[Key, Value]
+	copy(keys[:i], t.keys[:i])
+	copy(values[:i], t.values[:i])
+	keys[i] = p.K
+	values[i] = p.V
+	copy(keys[i+1:], t.keys[i:t.pairN])
+	copy(values[i+1:], t.values[i:t.pairN])
+	if t.subs[0] != nil {
+		copy(subs[:i], t.subs[:i])
+		subs[i] = left
+		subs[i+1] = right
+		copy(subs[i+2:], t.subs[i+1:t.pairN+1])
+	}
+
+	if t.pairN < 3 {
+		c = &node[Key, Value]{pairN: t.pairN + 1}
+		copy(c.keys[:], keys[:c.pairN])
+		copy(c.values[:], values[:c.pairN])
+		copy(c.subs[:], subs[:c.pairN+1])
+		return c, split, nil, true
+	}
+
+	// overflow
+	c = &node[Key, Value]{pairN: 2}
+	copy(c.keys[:], keys[:2])
+	copy(c.values[:], values[:2])
+	copy(c.subs[:], subs[:3])
+	splitRight = &node[Key, Value]{pairN: 1}
+	splitRight.keys[0] = keys[3]
+	splitRight.values[0] = values[3]
+	copy(splitRight.subs[:], subs[3:])
+	split.K = keys[2]
+	split.V = values[2]
+	return c, split, splitRight, true
+}
+
+// Copy returns a new node with the content of t, excluding the link above.
+func (t *node[Key, Value]) copy() *node[Key, Value] {
+	c := &node[Key, Value]{pairN: t.pairN}
+	copy(c.keys[:], t.keys[:t.pairN])
+	copy(c.values[:], t.values[:t.pairN])
+	if t.subs[0] != nil {
+		copy(c.subs[:], t.subs[:t.pairN+1])
+	}
+	return c
+}
+
+// Size returns the number of Keys in the Snapshot.
+func (s Snapshot[Key, Value]) Size() int {
+	return s.top.size() // nil safe
+}
+
+// Find returns the Value assigned to the Key.
+func (s Snapshot[Key, Value]) Find(k Key) (Value, bool) {
+	vp := s.top.findPointer(k) // nil safe
+	if vp == nil {
+		var zero Value
+		return zero, false
+	}
+	return *vp, true
+}
+
+// AppendKeys appends each Key in the Snapshot to dst, ascending in Key order,
+// and it returns the extended buffer.
+func (s Snapshot[Key, Value]) AppendKeys(dst []Key) []Key {
+	return s.top.appendKeys(dst)
+}
+
+// AppendValues appends each Value in the Snapshot to dst, ascending in Key
+// order, and it returns the extended buffer.
+func (s Snapshot[Key, Value]) AppendValues(dst []Value) []Value {
+	return s.top.appendValues(dst)
+}
+
+// AppendPairs appends each Key–Value pair in the Snapshot to keys and values,
+// ascending in Key order, and it returns the extended buffers.
+func (s Snapshot[Key, Value]) AppendPairs(keys []Key, values []Value) ([]Key, []Value) {
+	return s.top.appendPairs(keys, values)
+}
+
+// Ascend calls f for each Key–Value pair in the Snapshot, ascending in Key
+// order, until f returns false.
+func (s Snapshot[Key, Value]) Ascend(f func(Key, Value) bool) {
+	s.top.ascend(f)
+}
+
+// Descend calls f for each Key–Value pair in the Snapshot, descending in Key
+// order, until f returns false.
+func (s Snapshot[Key, Value]) Descend(f func(Key, Value) bool) {
+	s.top.descend(f)
+}
+
+func (t *node[Key, Value]) ascend(f func(Key, Value) bool) bool {
+	if t == nil {
+		return true
+	}
+	for i := 0; i < t.pairN; i++ {
+		if !t.subs[i].ascend(f) || !f(t.keys[i], t.values[i]) {
+			return false
+		}
+	}
+	return t.subs[t.pairN].ascend(f)
+}
+
+func (t *node[Key, Value]) descend(f func(Key, Value) bool) bool {
+	if t == nil {
+		return true
+	}
+	for i := t.pairN - 1; i >= 0; i-- {
+		if !t.subs[i+1].descend(f) || !f(t.keys[i], t.values[i]) {
+			return false
+		}
+	}
+	return t.subs[0].descend(f)
+}
@@ -0,0 +1,136 @@
+package pile_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+// Run with the race detector enabled (go test -race) for full effect.
+func TestSnapshotMapInsert(t *testing.T) {
+	const keyN, readerN = 2000, 4
+	var m pile.SnapshotMap[int, int]
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for r := 0; r < readerN; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var lastSize int
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				s := m.Snapshot()
+				keys, values := s.AppendPairs(nil, nil)
+				if len(keys) < lastSize {
+					t.Errorf("snapshot got %d keys after %d", len(keys), lastSize)
+				}
+				lastSize = len(keys)
+				if n := s.Size(); n != len(keys) {
+					t.Errorf("snapshot size %d with %d keys", n, len(keys))
+				}
+				for i, k := range keys {
+					if i != 0 && k <= keys[i-1] {
+						t.Errorf("snapshot got key %d after %d", k, keys[i-1])
+					}
+					if values[i] != -k {
+						t.Errorf("snapshot got key %d with value %d, want %d", k, values[i], -k)
+					}
+					if v, ok := s.Find(k); !ok || v != -k {
+						t.Errorf("snapshot find %d got (%d, %t), want (%d, true)", k, v, ok, -k)
+					}
+				}
+				if t.Failed() {
+					return
+				}
+			}
+		}()
+	}
+
+	for _, k := range rand.New(rand.NewSource(42)).Perm(keyN) {
+		if !m.Insert(k, -k) {
+			t.Errorf("insert %d got false", k)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if n := m.Size(); n != keyN {
+		t.Errorf("got size %d, want %d", n, keyN)
+	}
+	if m.Insert(7, 7) {
+		t.Error("insert on present key got true")
+	}
+	if v, ok := m.Find(7); !ok || v != -7 {
+		t.Errorf("find 7 got (%d, %t), want (-7, true)", v, ok)
+	}
+}
+
+// Run with the race detector enabled (go test -race) for full effect.
+func TestSnapshotMapPut(t *testing.T) {
+	const keyN, generationN = 100, 50
+	var m pile.SnapshotMap[int, int]
+	for k := 0; k < keyN; k++ {
+		m.Put(k, 0)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			// writer updates in ascending key order
+			s := m.Snapshot()
+			first, _ := s.Find(0)
+			s.Ascend(func(k, v int) bool {
+				if v != first && v != first-1 {
+					t.Errorf("snapshot got key %d generation %d, while key 0 has %d", k, v, first)
+					return false
+				}
+				return true
+			})
+			if t.Failed() {
+				return
+			}
+		}
+	}()
+
+	for g := 1; g <= generationN; g++ {
+		for k := 0; k < keyN; k++ {
+			if !m.Update(k, g) {
+				t.Fatalf("update %d got false", k)
+			}
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if m.Update(keyN, 1) {
+		t.Error("update on absent key got true")
+	}
+	var want int
+	m.Snapshot().Descend(func(k, v int) bool {
+		want++
+		if v != generationN {
+			t.Errorf("got key %d generation %d, want %d", k, v, generationN)
+		}
+		return true
+	})
+	if want != keyN {
+		t.Errorf("descend got %d keys, want %d", want, keyN)
+	}
+}
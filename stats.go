@@ -0,0 +1,74 @@
+package pile
+
+import "unsafe"
+
+// Stats describes the B-tree of a Map.
+type Stats struct {
+	// Height is the number of levels in the B-tree.
+	// An empty Map has zero height.
+	Height int
+
+	// LevelNodeN has the number of nodes per level, from the top
+	// down to the ground level.
+	LevelNodeN []int
+
+	NodeN int // total number of nodes in use
+	PairN int // total number of Key–Value pairs
+
+	// SpareNodeN has the number of nodes allocated for future use.
+	// A shared Arena counts for each Map with UseArena.
+	SpareNodeN int
+
+	// NodeSize is the number of bytes per node, which includes
+	// room for three Key–Value pairs.
+	NodeSize int
+
+	// ByteN is the memory size of all nodes allocated, in use or
+	// spare. Memory referenced by Keys or Values, like the bytes of
+	// a string, is not included.
+	ByteN int
+}
+
+// PairsPerNode returns the average number of Key–Value pairs per node, which
+// ranges from one to three. An empty Map has zero pairs per node.
+func (s *Stats) PairsPerNode() float64 {
+	if s.NodeN == 0 {
+		return 0
+	}
+	return float64(s.PairN) / float64(s.NodeN)
+}
+
+// Stats returns a full analysis of the B-tree, which requires a walk over each
+// node in the Map.
+func (m *Map[Key, Value]) Stats() Stats {
+	var s Stats
+	s.NodeSize = int(unsafe.Sizeof(node[Key, Value]{}))
+	if m.arena != nil {
+		s.SpareNodeN = m.arena.SpareNodeN()
+	} else {
+		s.SpareNodeN = m.nodes.SpareNodeN()
+	}
+
+	// scan line starts at top
+	var nodeRow []*node[Key, Value]
+	if m.top != nil {
+		nodeRow = append(nodeRow, m.top)
+	}
+	for len(nodeRow) != 0 {
+		s.Height++
+		s.LevelNodeN = append(s.LevelNodeN, len(nodeRow))
+		s.NodeN += len(nodeRow)
+
+		var subs []*node[Key, Value]
+		for _, t := range nodeRow {
+			s.PairN += t.pairN
+			if t.subs[0] != nil {
+				subs = append(subs, t.subs[:t.pairN+1]...)
+			}
+		}
+		nodeRow = subs
+	}
+
+	s.ByteN = (s.NodeN + s.SpareNodeN) * s.NodeSize
+	return s
+}
@@ -0,0 +1,79 @@
+package pile
+
+import (
+	"math/rand"
+	"testing"
+	"unsafe"
+)
+
+func TestStats(t *testing.T) {
+	var empty Map[int, string]
+	if s := empty.Stats(); s.Height != 0 || s.NodeN != 0 || s.PairN != 0 || s.ByteN != 0 || s.PairsPerNode() != 0 {
+		t.Errorf("empty Map got %+v", s)
+	}
+
+	var m Map[int, string]
+	for _, k := range rand.New(rand.NewSource(7)).Perm(1000) {
+		m.Insert(k, "x")
+	}
+	s := m.Stats()
+	if s.Height != m.height() {
+		t.Errorf("got height %d, want %d", s.Height, m.height())
+	}
+	if len(s.LevelNodeN) != s.Height || s.LevelNodeN[0] != 1 {
+		t.Errorf("got level node counts %d for height %d", s.LevelNodeN, s.Height)
+	}
+	var nodeN int
+	for _, n := range s.LevelNodeN {
+		nodeN += n
+	}
+	if nodeN != s.NodeN {
+		t.Errorf("got %d nodes in total, while levels sum up to %d", s.NodeN, nodeN)
+	}
+	if s.PairN != 1000 {
+		t.Errorf("got %d pairs, want 1000", s.PairN)
+	}
+	if ppn := s.PairsPerNode(); ppn < 1 || ppn > 3 {
+		t.Errorf("got %f pairs per node, want 1 to 3", ppn)
+	}
+	// batches of 1, 2, 4, 8, 16, 32, 64, 128, 256 and 512
+	if s.NodeN+s.SpareNodeN != 1023 {
+		t.Errorf("got %d nodes plus %d spare, want 1023 in total", s.NodeN, s.SpareNodeN)
+	}
+	if s.ByteN != (s.NodeN+s.SpareNodeN)*s.NodeSize {
+		t.Errorf("got %d bytes for %d nodes of %d bytes plus %d spare", s.ByteN, s.NodeN, s.NodeSize, s.SpareNodeN)
+	}
+}
+
+// The storage-overhead claims on the Map documentation apply to 64-bit.
+func TestStatsOverhead(t *testing.T) {
+	if unsafe.Sizeof(uintptr(0)) != 8 {
+		t.Skip("not a 64-bit platform")
+	}
+	pairSize := int(unsafe.Sizeof(pair[int, string]{}))
+	if pairSize != 24 {
+		t.Fatalf("got pair size %d, want 24", pairSize)
+	}
+
+	feeds := map[string][]int{
+		"Sequential": make([]int, 10000),
+		"Random":     rand.New(rand.NewSource(1)).Perm(10000),
+	}
+	for i := range feeds["Sequential"] {
+		feeds["Sequential"][i] = i
+	}
+
+	for name, feed := range feeds {
+		var m Map[int, string]
+		for _, k := range feed {
+			m.Insert(k, "x")
+		}
+		s := m.Stats()
+
+		overhead := float64(s.NodeN*s.NodeSize)/float64(s.PairN) - float64(pairSize)
+		t.Logf("%s overhead is %.1f bytes per pair with %.2f pairs per node", name, overhead, s.PairsPerNode())
+		if overhead < 16 || overhead > 48+2*float64(pairSize) {
+			t.Errorf("%s got an overhead of %.1f bytes per pair, want 16 to %d", name, overhead, 48+2*pairSize)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package pile
+
+// SubMaps registers B–Value pairs per A, with a Map for each A. Maps are created
+// on demand, and they are dropped once empty. Such Maps tend to be small, and
+// frequent in creation. They all allocate from the one Arena, which recycles
+// nodes across Maps. Do not copy the subMaps struct.
+type subMaps[A, B Sortable, Value any] struct {
+	byA   Map[A, *Map[B, Value]]
+	nodes Arena[B, Value]
+	fix   func(*node[B, Value]) // hook for each Map, if any
+}
+
+// Find returns the Map on a, if any.
+func (x *subMaps[A, B, Value]) find(a A) (*Map[B, Value], bool) {
+	return x.byA.Find(a)
+}
+
+// SubFor returns the Map on a, which is created on demand.
+func (x *subMaps[A, B, Value]) subFor(a A) *Map[B, Value] {
+	sub, ok := x.byA.Find(a)
+	if !ok {
+		sub = new(Map[B, Value])
+		sub.UseArena(&x.nodes)
+		sub.fix = x.fix
+		x.byA.Insert(a, sub)
+	}
+	return sub
+}
+
+// Delete removes b from the Map on a, and it returns the Value removed, with
+// false for none. The Map on a is dropped once empty.
+func (x *subMaps[A, B, Value]) delete(a A, b B) (Value, bool) {
+	sub, ok := x.byA.Find(a)
+	if !ok {
+		var zero Value
+		return zero, false
+	}
+	v, ok := sub.Delete(b)
+	if ok && sub.top == nil {
+		x.byA.Delete(a)
+	}
+	return v, ok
+}
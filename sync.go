@@ -0,0 +1,140 @@
+package pile
+
+import "sync"
+
+// SyncMap provides sorted Key–Value registration for concurrent use. Multiple
+// goroutines may invoke methods simultaneously. The zero SyncMap is empty and
+// ready for use. Do not copy the SyncMap struct.
+//
+// Cursors are not available because any Insert from another goroutine renders
+// them invalid. Use the callback-style range methods instead. They hold a read
+// lock for the entire walk. Callbacks must not invoke any methods on the same
+// SyncMap.
+type SyncMap[Key Sortable, Value any] struct {
+	mutex sync.RWMutex
+	m     Map[Key, Value]
+}
+
+// Size returns the number of Keys in the Map.
+func (m *SyncMap[Key, Value]) Size() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Size()
+}
+
+// Find returns the Value assigned to the Key.
+func (m *SyncMap[Key, Value]) Find(k Key) (Value, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Find(k)
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (m *SyncMap[Key, Value]) Insert(k Key, v Value) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.m.Insert(k, v)
+}
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (m *SyncMap[Key, Value]) Update(k Key, v Value) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.m.Update(k, v)
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not.
+func (m *SyncMap[Key, Value]) Put(k Key, v Value) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.m.Put(k, v)
+}
+
+// Delete removes the Key from the Map, and it returns the Value removed, with
+// false for none.
+func (m *SyncMap[Key, Value]) Delete(k Key) (Value, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.m.Delete(k)
+}
+
+// Clear removes all Keys from the Map. The nodes are kept for reuse.
+func (m *SyncMap[Key, Value]) Clear() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.m.Clear()
+}
+
+// Release removes all Keys from the Map, and it frees all memory allocated.
+func (m *SyncMap[Key, Value]) Release() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.m.Release()
+}
+
+// AppendKeys appends each Key in the Map to dst, ascending in Key order, and it
+// returns the extended buffer.
+func (m *SyncMap[Key, Value]) AppendKeys(dst []Key) []Key {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.AppendKeys(dst)
+}
+
+// AppendValues appends each Value in the Map to dst, ascending in Key order,
+// and it returns the extended buffer.
+func (m *SyncMap[Key, Value]) AppendValues(dst []Value) []Value {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.AppendValues(dst)
+}
+
+// AppendPairs appends each Key–Value pair in the Map to keys and values,
+// ascending in Key order, and it returns the extended buffers.
+func (m *SyncMap[Key, Value]) AppendPairs(keys []Key, values []Value) ([]Key, []Value) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.AppendPairs(keys, values)
+}
+
+// Ascend calls f for each Key–Value pair in the Map, ascending in Key order,
+// until f returns false.
+func (m *SyncMap[Key, Value]) Ascend(f func(Key, Value) bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for c, ok := m.m.Least(); ok && f(c.Key(), c.Value()); ok = c.Ascend() {
+	}
+}
+
+// Descend calls f for each Key–Value pair in the Map, descending in Key order,
+// until f returns false.
+func (m *SyncMap[Key, Value]) Descend(f func(Key, Value) bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for c, ok := m.m.Most(); ok && f(c.Key(), c.Value()); ok = c.Descend() {
+	}
+}
+
+// AscendAt calls f for each Key–Value pair in the Map, ascending in Key order
+// since the Key, until f returns false. The return is false when the Key is
+// absent, in which case f is not called.
+func (m *SyncMap[Key, Value]) AscendAt(k Key, f func(Key, Value) bool) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	c, found := m.m.At(k)
+	for ok := found; ok && f(c.Key(), c.Value()); ok = c.Ascend() {
+	}
+	return found
+}
+
+// DescendAt calls f for each Key–Value pair in the Map, descending in Key order
+// since the Key, until f returns false. The return is false when the Key is
+// absent, in which case f is not called.
+func (m *SyncMap[Key, Value]) DescendAt(k Key, f func(Key, Value) bool) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	c, found := m.m.At(k)
+	for ok := found; ok && f(c.Key(), c.Value()); ok = c.Descend() {
+	}
+	return found
+}
@@ -0,0 +1,97 @@
+package pile_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func TestSyncMap(t *testing.T) {
+	const writerN, keyN = 4, 1000
+	var m pile.SyncMap[int, int]
+
+	var wg sync.WaitGroup
+	for w := 0; w < writerN; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < keyN; i += writerN {
+				if !m.Insert(i, i+100) {
+					t.Errorf("insert %d got false", i)
+				}
+			}
+		}(w)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			last := -1
+			m.Ascend(func(k, v int) bool {
+				if k <= last {
+					t.Errorf("ascend got key %d after %d", k, last)
+				}
+				if v != k+100 {
+					t.Errorf("ascend got key %d with value %d, want %d", k, v, k+100)
+				}
+				last = k
+				return true
+			})
+			if last == keyN-1 {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	<-done
+
+	if n := m.Size(); n != keyN {
+		t.Errorf("got size %d, want %d", n, keyN)
+	}
+	keys := m.AppendKeys(nil)
+	for i, k := range keys {
+		if k != i {
+			t.Fatalf("got key № %d %d, want %d", i+1, k, i)
+		}
+	}
+}
+
+func TestSyncMapRange(t *testing.T) {
+	var m pile.SyncMap[string, int]
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	m.Put("d", 4)
+
+	var got []string
+	if !m.DescendAt("c", func(k string, v int) bool {
+		got = append(got, k)
+		return k != "b"
+	}) {
+		t.Fatal("DescendAt got false for present key")
+	}
+	if len(got) != 2 || got[0] != "c" || got[1] != "b" {
+		t.Errorf("DescendAt since c until b got %q", got)
+	}
+
+	got = got[:0]
+	if !m.AscendAt("b", func(k string, v int) bool {
+		got = append(got, k)
+		return true
+	}) {
+		t.Fatal("AscendAt got false for present key")
+	}
+	if len(got) != 3 || got[0] != "b" || got[2] != "d" {
+		t.Errorf("AscendAt since b got %q", got)
+	}
+
+	if m.AscendAt("x", func(k string, v int) bool {
+		t.Errorf("AscendAt on absent key called with %q", k)
+		return true
+	}) {
+		t.Error("AscendAt got true for absent key")
+	}
+}
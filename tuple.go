@@ -0,0 +1,203 @@
+package pile
+
+// Tuple2 is a composite Key, ordered on A first, and then on B.
+type Tuple2[A, B Sortable] struct {
+	A A
+	B B
+}
+
+// Tuple3 is a composite Key, ordered on A first, then on B, and then on C.
+type Tuple3[A, B, C Sortable] struct {
+	A A
+	B B
+	C C
+}
+
+// Map2 provides sorted Tuple2–Value registration. Entries with an equal first
+// component reside in a Map of their own, which makes PrefixRange a lookup on
+// the first component plus an iteration. The zero Map2 is empty and ready for
+// use. Do not copy the Map2 struct.
+type Map2[A, B Sortable, Value any] struct {
+	check noCopy
+
+	subs subMaps[A, B, Value] // per first component
+	size int
+}
+
+// Size returns the number of Keys in the Map2.
+func (m *Map2[A, B, Value]) Size() int { return m.size }
+
+// Find returns the Value assigned to the Key.
+func (m *Map2[A, B, Value]) Find(k Tuple2[A, B]) (Value, bool) {
+	sub, ok := m.subs.find(k.A)
+	if !ok {
+		var zero Value
+		return zero, false
+	}
+	return sub.Find(k.B)
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (m *Map2[A, B, Value]) Insert(k Tuple2[A, B], v Value) bool {
+	if !m.subs.subFor(k.A).Insert(k.B, v) {
+		return false
+	}
+	m.size++
+	return true
+}
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (m *Map2[A, B, Value]) Update(k Tuple2[A, B], v Value) bool {
+	sub, ok := m.subs.find(k.A)
+	return ok && sub.Update(k.B, v)
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not.
+func (m *Map2[A, B, Value]) Put(k Tuple2[A, B], v Value) {
+	sub := m.subs.subFor(k.A)
+	if sub.Insert(k.B, v) {
+		m.size++
+	} else {
+		sub.Update(k.B, v)
+	}
+}
+
+// Delete removes the Key from the Map2, and it returns the Value removed, with
+// false for none.
+func (m *Map2[A, B, Value]) Delete(k Tuple2[A, B]) (Value, bool) {
+	v, ok := m.subs.delete(k.A, k.B)
+	if ok {
+		m.size--
+	}
+	return v, ok
+}
+
+// PrefixRange calls f for each Key–Value pair with a first component equal to
+// a, ascending in Key order, until f returns false.
+func (m *Map2[A, B, Value]) PrefixRange(a A, f func(Tuple2[A, B], Value) bool) {
+	sub, ok := m.subs.find(a)
+	if !ok {
+		return
+	}
+	for c, ok := sub.Least(); ok; ok = c.Ascend() {
+		if !f(Tuple2[A, B]{a, c.Key()}, c.Value()) {
+			return
+		}
+	}
+}
+
+// Ascend calls f for each Key–Value pair in the Map2, ascending in Key order,
+// until f returns false.
+func (m *Map2[A, B, Value]) Ascend(f func(Tuple2[A, B], Value) bool) {
+	m.ascend(func(a A, b B, v Value) bool {
+		return f(Tuple2[A, B]{a, b}, v)
+	})
+}
+
+func (m *Map2[A, B, Value]) ascend(f func(A, B, Value) bool) bool {
+	for ac, ok := m.subs.byA.Least(); ok; ok = ac.Ascend() {
+		a, sub := ac.Key(), ac.Value()
+		for c, ok := sub.Least(); ok; ok = c.Ascend() {
+			if !f(a, c.Key(), c.Value()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Map3 provides sorted Tuple3–Value registration. Entries with an equal first
+// component reside in a Map2 of their own, which makes PrefixRange a lookup on
+// the first component plus an iteration. The zero Map3 is empty and ready for
+// use. Do not copy the Map3 struct.
+type Map3[A, B, C Sortable, Value any] struct {
+	check noCopy
+
+	byA  Map[A, *Map2[B, C, Value]]
+	size int
+}
+
+// Size returns the number of Keys in the Map3.
+func (m *Map3[A, B, C, Value]) Size() int { return m.size }
+
+// Find returns the Value assigned to the Key.
+func (m *Map3[A, B, C, Value]) Find(k Tuple3[A, B, C]) (Value, bool) {
+	sub, ok := m.byA.Find(k.A)
+	if !ok {
+		var zero Value
+		return zero, false
+	}
+	return sub.Find(Tuple2[B, C]{k.B, k.C})
+}
+
+// Insert assigns the Value to the Key if and only if the Key is absent.
+func (m *Map3[A, B, C, Value]) Insert(k Tuple3[A, B, C], v Value) bool {
+	sub, ok := m.byA.Find(k.A)
+	if !ok {
+		sub = new(Map2[B, C, Value])
+		m.byA.Insert(k.A, sub)
+	}
+	if !sub.Insert(Tuple2[B, C]{k.B, k.C}, v) {
+		return false
+	}
+	m.size++
+	return true
+}
+
+// Update assigns the Value to the Key if and only if the Key is present.
+func (m *Map3[A, B, C, Value]) Update(k Tuple3[A, B, C], v Value) bool {
+	sub, ok := m.byA.Find(k.A)
+	return ok && sub.Update(Tuple2[B, C]{k.B, k.C}, v)
+}
+
+// Put assigns the Value to the Key regardless whether the Key is present or
+// not.
+func (m *Map3[A, B, C, Value]) Put(k Tuple3[A, B, C], v Value) {
+	if !m.Insert(k, v) {
+		m.Update(k, v)
+	}
+}
+
+// Delete removes the Key from the Map3, and it returns the Value removed, with
+// false for none.
+func (m *Map3[A, B, C, Value]) Delete(k Tuple3[A, B, C]) (Value, bool) {
+	sub, ok := m.byA.Find(k.A)
+	if !ok {
+		var zero Value
+		return zero, false
+	}
+	v, ok := sub.Delete(Tuple2[B, C]{k.B, k.C})
+	if ok {
+		m.size--
+		if sub.size == 0 {
+			m.byA.Delete(k.A)
+		}
+	}
+	return v, ok
+}
+
+// PrefixRange calls f for each Key–Value pair with a first component equal to
+// a, ascending in Key order, until f returns false.
+func (m *Map3[A, B, C, Value]) PrefixRange(a A, f func(Tuple3[A, B, C], Value) bool) {
+	sub, ok := m.byA.Find(a)
+	if !ok {
+		return
+	}
+	sub.ascend(func(b B, c C, v Value) bool {
+		return f(Tuple3[A, B, C]{a, b, c}, v)
+	})
+}
+
+// Ascend calls f for each Key–Value pair in the Map3, ascending in Key order,
+// until f returns false.
+func (m *Map3[A, B, C, Value]) Ascend(f func(Tuple3[A, B, C], Value) bool) {
+	for ac, ok := m.byA.Least(); ok; ok = ac.Ascend() {
+		a := ac.Key()
+		if !ac.Value().ascend(func(b B, c C, v Value) bool {
+			return f(Tuple3[A, B, C]{a, b, c}, v)
+		}) {
+			return
+		}
+	}
+}
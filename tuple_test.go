@@ -0,0 +1,102 @@
+package pile_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pascaldekloe/pile"
+)
+
+func ExampleMap2_PrefixRange() {
+	var events pile.Map2[string, int64, string]
+	events.Put(pile.Tuple2[string, int64]{"acme", 1700000300}, "login")
+	events.Put(pile.Tuple2[string, int64]{"initech", 1700000100}, "signup")
+	events.Put(pile.Tuple2[string, int64]{"acme", 1700000200}, "signup")
+
+	events.PrefixRange("acme", func(k pile.Tuple2[string, int64], v string) bool {
+		fmt.Println(k.A, k.B, v)
+		return true
+	})
+	// Output:
+	// acme 1700000200 signup
+	// acme 1700000300 login
+}
+
+func TestMap3(t *testing.T) {
+	type key = pile.Tuple3[uint8, string, int]
+	var m pile.Map3[uint8, string, int, int]
+	reference := make(map[key]int)
+
+	r := rand.New(rand.NewSource(8))
+	for i := 0; i < 5000; i++ {
+		k := key{uint8(r.Intn(4)), string(rune('a' + r.Intn(5))), r.Intn(20)}
+		switch r.Intn(3) {
+		case 0:
+			want, present := reference[k]
+			if v, ok := m.Delete(k); ok != present || v != want {
+				t.Fatalf("delete %v got (%d, %t), want (%d, %t)", k, v, ok, want, present)
+			}
+			delete(reference, k)
+		case 1:
+			_, present := reference[k]
+			if got := m.Insert(k, i); got == present {
+				t.Fatalf("insert %v got %t with presence %t", k, got, present)
+			}
+			if !present {
+				reference[k] = i
+			}
+		default:
+			m.Put(k, i)
+			reference[k] = i
+		}
+	}
+
+	if got, want := m.Size(), len(reference); got != want {
+		t.Errorf("got size %d, want %d", got, want)
+	}
+	for k, want := range reference {
+		if v, ok := m.Find(k); !ok || v != want {
+			t.Errorf("find %v got (%d, %t), want %d", k, v, ok, want)
+		}
+	}
+
+	want := make([]key, 0, len(reference))
+	for k := range reference {
+		want = append(want, k)
+	}
+	sort.Slice(want, func(i, j int) bool {
+		a, b := want[i], want[j]
+		if a.A != b.A {
+			return a.A < b.A
+		}
+		if a.B != b.B {
+			return a.B < b.B
+		}
+		return a.C < b.C
+	})
+	var got []key
+	m.Ascend(func(k key, v int) bool {
+		got = append(got, k)
+		return true
+	})
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ascend got %v\nwant %v", got, want)
+	}
+
+	var prefixed []key
+	m.PrefixRange(2, func(k key, v int) bool {
+		prefixed = append(prefixed, k)
+		return true
+	})
+	var wantPrefixed []key
+	for _, k := range want {
+		if k.A == 2 {
+			wantPrefixed = append(wantPrefixed, k)
+		}
+	}
+	if fmt.Sprint(prefixed) != fmt.Sprint(wantPrefixed) {
+		t.Errorf("prefix range got %v\nwant %v", prefixed, wantPrefixed)
+	}
+}
@@ -0,0 +1,95 @@
+package pile
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Validate checks the B-tree of the Map for consistency. The Map should always
+// be valid, unless some code caused memory corruption, like a data race does.
+// The error reports the first violation found, including its node path from the
+// top.
+func (m *Map[Key, Value]) Validate() error {
+	if m.top == nil {
+		return nil
+	}
+	if m.top.above != nil {
+		return fmt.Errorf("pile: node top links to a node above")
+	}
+
+	var height int
+	for t := m.top; t != nil; t = t.subs[0] {
+		height++
+	}
+	v := validation[Key, Value]{groundLevel: height - 1}
+	return v.node(m.top, nil, nil)
+}
+
+// Validation is the state of a Map Validate.
+type validation[Key Sortable, Value any] struct {
+	groundLevel int   // depth of nodes without subnodes
+	path        []int // subnode indices from top
+}
+
+// Node validates t, plus all nodes below, against the Key bounds, if any.
+func (v *validation[Key, Value]) node(t *node[Key, Value], least, most *Key) error {
+	if t.pairN < 1 || t.pairN > 3 {
+		return fmt.Errorf("pile: node %s has %d pairs; want 1 to 3", v.pathString(), t.pairN)
+	}
+
+	for i := 1; i < t.pairN; i++ {
+		if !(t.keys[i-1] < t.keys[i]) {
+			return fmt.Errorf("pile: node %s has key %#v at pair index %d, followed by key %#v", v.pathString(), t.keys[i-1], i-1, t.keys[i])
+		}
+	}
+	if least != nil && !(*least < t.keys[0]) {
+		return fmt.Errorf("pile: node %s has key %#v at pair index 0, while the node above has key %#v on the left", v.pathString(), t.keys[0], *least)
+	}
+	if most != nil && !(t.keys[t.pairN-1] < *most) {
+		return fmt.Errorf("pile: node %s has key %#v at pair index %d, while the node above has key %#v on the right", v.pathString(), t.keys[t.pairN-1], t.pairN-1, *most)
+	}
+
+	if len(v.path) == v.groundLevel {
+		for i := 0; i <= t.pairN; i++ {
+			if t.subs[i] != nil {
+				return fmt.Errorf("pile: node %s on ground level %d has a subnode at index %d", v.pathString(), v.groundLevel, i)
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i <= t.pairN; i++ {
+		sub := t.subs[i]
+		if sub == nil {
+			return fmt.Errorf("pile: node %s above ground level %d has no subnode at index %d", v.pathString(), v.groundLevel, i)
+		}
+		if sub.above != t {
+			return fmt.Errorf("pile: node %s has subnode at index %d linked to another node above", v.pathString(), i)
+		}
+
+		subLeast, subMost := least, most
+		if i > 0 {
+			subLeast = &t.keys[i-1]
+		}
+		if i < t.pairN {
+			subMost = &t.keys[i]
+		}
+		v.path = append(v.path, i)
+		if err := v.node(sub, subLeast, subMost); err != nil {
+			return err
+		}
+		v.path = v.path[:len(v.path)-1]
+	}
+	return nil
+}
+
+// PathString returns the node path in Go notation.
+func (v *validation[Key, Value]) pathString() string {
+	buf := []byte("top")
+	for _, i := range v.path {
+		buf = append(buf, ".subs["...)
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		buf = append(buf, ']')
+	}
+	return string(buf)
+}
@@ -0,0 +1,96 @@
+package pile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	newMap := func() *Map[int, int] {
+		m := new(Map[int, int])
+		for i := 0; i < 40; i++ {
+			m.Insert(i, i)
+		}
+		if err := m.Validate(); err != nil {
+			t.Fatal("valid Map got error:", err)
+		}
+		if m.height() < 3 {
+			t.Fatalf("got height %d, want 3 or more", m.height())
+		}
+		return m
+	}
+
+	tests := []struct {
+		corrupt func(m *Map[int, int])
+		want    string
+	}{
+		{func(m *Map[int, int]) {
+			m.top.pairN = 0
+		}, "pile: node top has 0 pairs; want 1 to 3"},
+		{func(m *Map[int, int]) {
+			l := m.top.subs[1]
+			l.keys[0], l.keys[1] = l.keys[1], l.keys[0]
+		}, "pile: node top.subs[1] has key"},
+		{func(m *Map[int, int]) {
+			m.top.subs[0].subs[0].keys[0] = 99
+		}, "pile: node top.subs[0].subs[0] has key 99 at pair index"},
+		{func(m *Map[int, int]) {
+			m.top.subs[1].subs[0].keys[0] = -1
+		}, "pile: node top.subs[1].subs[0] has key -1 at pair index 0, while the node above has key"},
+		{func(m *Map[int, int]) {
+			m.top.subs[0].subs[1].above = m.top
+		}, "pile: node top.subs[0] has subnode at index 1 linked to another node above"},
+		{func(m *Map[int, int]) {
+			ground := m.top.subs[0].subs[0]
+			for ground.subs[0] != nil {
+				ground = ground.subs[0]
+			}
+			ground.subs[1] = m.top
+		}, "has a subnode at index 1"},
+		{func(m *Map[int, int]) {
+			m.top.subs[0].subs[1] = nil
+		}, "pile: node top.subs[0] above ground level 3 has no subnode at index 1"},
+	}
+	for _, test := range tests {
+		m := newMap()
+		test.corrupt(m)
+		err := m.Validate()
+		if err == nil {
+			t.Errorf("got no error, want %q", test.want)
+		} else if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("got error %q, want %q", err, test.want)
+		}
+	}
+}
+
+func FuzzMap(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	f.Add([]byte{9, 8, 7, 6, 5, 4, 3, 2, 1})
+	f.Add([]byte{5, 1, 9, 5, 1, 9, 3, 7, 2})
+	f.Fuzz(func(t *testing.T, keys []byte) {
+		reference := make(map[byte]int, len(keys))
+		var m Map[byte, int]
+		for i, k := range keys {
+			switch i % 3 {
+			case 0:
+				m.Put(k, i)
+				reference[k] = i
+			case 1:
+				if m.Insert(k, i) {
+					reference[k] = i
+				}
+			case 2:
+				v, ok := m.Delete(k)
+				want, present := reference[k]
+				if ok != present || v != want {
+					t.Fatalf("delete key %d got (%d, %t), want (%d, %t)", k, v, ok, want, present)
+				}
+				delete(reference, k)
+			}
+			if err := m.Validate(); err != nil {
+				t.Fatalf("invalid after operation № %d on key %d: %s", i+1, k, err)
+			}
+		}
+		verifyMapEqual(t, "fuzz", &m, reference)
+	})
+}
@@ -0,0 +1,94 @@
+package pile
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWideMap(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3} {
+		var m WideMap[uint16, int]
+		reference := make(map[uint16]int)
+		r := rand.New(rand.NewSource(seed))
+		for i := 0; i < 5000; i++ {
+			k := uint16(r.Intn(4000))
+			if i&1 == 0 {
+				m.Put(k, i)
+				reference[k] = i
+			} else if m.Insert(k, i) {
+				if _, ok := reference[k]; ok {
+					t.Fatalf("seed %d: insert of present key %d got true", seed, k)
+				}
+				reference[k] = i
+			} else if !m.Update(k, -i) {
+				t.Fatalf("seed %d: update of present key %d got false", seed, k)
+			} else {
+				reference[k] = -i
+			}
+		}
+
+		if n := m.Size(); n != len(reference) {
+			t.Errorf("seed %d: got size %d, want %d", seed, n, len(reference))
+		}
+		for k, want := range reference {
+			if got, ok := m.Find(k); !ok || got != want {
+				t.Errorf("seed %d: key %d got (%d, %t), want (%d, true)", seed, k, got, ok, want)
+			}
+		}
+		if _, ok := m.Find(4001); ok {
+			t.Errorf("seed %d: found absent key", seed)
+		}
+
+		keys := m.AppendKeys(nil)
+		values := m.AppendValues(nil)
+		if len(keys) != len(reference) || len(values) != len(reference) {
+			t.Fatalf("seed %d: got %d keys and %d values, want %d", seed, len(keys), len(values), len(reference))
+		}
+		for i, k := range keys {
+			if i != 0 && k <= keys[i-1] {
+				t.Errorf("seed %d: got key %d after %d", seed, k, keys[i-1])
+			}
+			if values[i] != reference[k] {
+				t.Errorf("seed %d: got value %d for key %d, want %d", seed, values[i], k, reference[k])
+			}
+		}
+		verifyWideNodes(t, m.top, true)
+	}
+}
+
+// VerifyWideNodes checks the pair count and the subnode presence.
+func verifyWideNodes[Key Sortable, Value any](t *testing.T, n *wideNode[Key, Value], top bool) {
+	if n.pairN > wideKeyN || (!top && n.pairN < wideKeyN/2) {
+		t.Errorf("node with %d pairs", n.pairN)
+	}
+	if n.subs[0] == nil {
+		for i, sub := range n.subs {
+			if sub != nil {
+				t.Errorf("ground node with %d pairs has subnode at index %d", n.pairN, i)
+			}
+		}
+		return
+	}
+	for i, sub := range n.subs {
+		if (sub != nil) != (i <= n.pairN) {
+			t.Errorf("node with %d pairs has subnode presence %t at index %d", n.pairN, sub != nil, i)
+		}
+		if sub != nil {
+			verifyWideNodes(t, sub, false)
+		}
+	}
+}
+
+func TestWideMapZero(t *testing.T) {
+	var m WideMap[string, int]
+	if m.Size() != 0 {
+		t.Error("zero WideMap not empty")
+	}
+	for i, s := range []string{"d", "b", "a", "c"} {
+		m.Put(s, i)
+	}
+	got := m.AppendKeys(nil)
+	if len(got) != 4 || got[0] != "a" || got[3] != "d" {
+		t.Errorf("got keys %q, want a to d", got)
+	}
+}